@@ -1,16 +1,107 @@
 // Code generated by tygo. DO NOT EDIT.
 
+//////////
+// source: errors.go
+
+/**
+ * ErrorResponse is the JSON body of errors clients are expected to act on,
+ * with a stable code alongside the readable message
+ */
+export interface ErrorResponse {
+  code: string;
+  message: string;
+  details?: { [key: string]: any};
+}
+export const ErrorCodeInvalidUsername = "invalid_username";
+export const ErrorCodeReservedUsername = "reserved_username";
+export const ErrorCodeUsernameTaken = "username_taken";
+export const ErrorCodeGameInProgress = "game_in_progress";
+export const ErrorCodeLobbyLocked = "lobby_locked";
+
 //////////
 // source: game.go
 
+export interface CreateGameRequest {
+  streamer_enabled?: boolean;
+  streamer_delay_seconds?: number /* int */;
+  streamer_delay_rounds?: number /* int */;
+  password?: string;
+  invite_only?: boolean;
+  visibility?: string; // public or private, private by default
+}
 export interface CreateGameResponse {
   game_id: string;
+  invite?: string; // For the creator to join an invite-only game with
+}
+export interface ListGamesResponse {
+  games: any /* views.LobbyListing */[];
+}
+export interface GameMetricsResponse {
+  game_id: string;
+  sent: { [key: any /* messages.MessageType */]: any /* game.MessageStats */};
+}
+export interface ServerMetricsResponse {
+  games: number /* int */;
+  reaper: any /* game.ReaperStats */;
 }
 export interface JoinGameRequest {
   game_id: string;
   username: string;
+  password?: string;
+  invite?: string;
 }
 export interface JoinGameResponse {
   game_id: string;
   player_id: string;
 }
+export interface CreateInviteRequest {
+  player_id: string;
+}
+export interface CreateInviteResponse {
+  game_id: string;
+  invite: string;
+}
+
+//////////
+// source: handshake.go
+
+
+//////////
+// source: lobby_ws.go
+
+
+//////////
+// source: matchmaking.go
+
+export interface EnqueueRequest {
+  username: string;
+  preferred_size: number /* int */; // Between 5 and 10
+}
+export interface EnqueueResponse {
+  ticket_id: string;
+}
+
+//////////
+// source: play_sse.go
+
+/**
+ * sseClient is a game.Client that streams messages as server-sent events
+ */
+
+//////////
+// source: play_ws.go
+
+/**
+ * wsClient sends messages over a websocket in the encoding negotiated for it
+ */
+
+//////////
+// source: rate_limit.go
+
+/**
+ * failureLimiter blocks a client after too many failed attempts within a window
+ */
+
+//////////
+// source: spectate_ws.go
+
@@ -11,14 +11,42 @@ export interface ActionMessage {
   target_index?: number /* int */;
   vote?: boolean;
   text?: string;
+  seat_order?: number /* int */[]; // Current seat indexes in their new order
 }
-export type ActionErrorReason = string;
-export const NotAllowed: ActionErrorReason = "Action not allowed";
-export const InvalidTarget: ActionErrorReason = "Targeted player is cannot be targeted for this action";
-export const CouldNotStart: ActionErrorReason = "Could not start game";
+/**
+ * ActionErrorCode is a stable, machine-readable reason an action was refused.
+ * Clients should switch on the code and only fall back to Reason for codes
+ * they don't know yet.
+ */
+export type ActionErrorCode = string;
+export const ErrorCodeUnknownAction: ActionErrorCode = "unknown_action";
+export const ErrorCodeUnknownPlayer: ActionErrorCode = "unknown_player";
+export const ErrorCodeNotHost: ActionErrorCode = "not_host";
+export const ErrorCodeNotYourTurn: ActionErrorCode = "not_your_turn";
+export const ErrorCodeWrongPhase: ActionErrorCode = "wrong_phase";
+export const ErrorCodeInvalidTarget: ActionErrorCode = "invalid_target";
+export const ErrorCodeTargetExecuted: ActionErrorCode = "target_executed";
+export const ErrorCodePlayerExecuted: ActionErrorCode = "player_executed";
+export const ErrorCodeTermLimited: ActionErrorCode = "term_limited";
+export const ErrorCodeAlreadyVoted: ActionErrorCode = "already_voted";
+export const ErrorCodeMissingVote: ActionErrorCode = "missing_vote";
+export const ErrorCodeInvalidCard: ActionErrorCode = "invalid_card";
+export const ErrorCodeInvalidChat: ActionErrorCode = "invalid_chat";
+export const ErrorCodeInvalidPlayerCount: ActionErrorCode = "invalid_player_count";
+export const ErrorCodeGracePeriod: ActionErrorCode = "grace_period";
+export const ErrorCodeInvalidSeatOrder: ActionErrorCode = "invalid_seat_order";
+export const ErrorCodeNotReady: ActionErrorCode = "players_not_ready";
+/**
+ * ActionErrorParams carries the details of an error, e.g. the expected phase
+ * for ErrorCodeWrongPhase or the target for ErrorCodeTermLimited
+ */
+export type ActionErrorParams = { [key: string]: any};
 export interface ActionErrorMessage {
   base_message: BaseMessage;
-  reason: ActionErrorReason;
+  action: Action;
+  code: ActionErrorCode;
+  params?: Record<string;
+  reason: string;
 }
 
 //////////
@@ -30,6 +58,20 @@ export const ConnectionErrorTypeFatal: ConnectionErrorType = 0;
 export const ConnectionErrorTypeGameInvalid: ConnectionErrorType = 1;
 export const ConnectionErrorTypePlayerInvalid: ConnectionErrorType = 2;
 export const ConnectionErrorTypeServerError: ConnectionErrorType = 3;
+export const ConnectionErrorTypeOutdatedClient: ConnectionErrorType = 4;
+/**
+ * The server is going down for a restart, the game survives it and
+ * clients should keep trying to reconnect
+ */
+export const ConnectionErrorTypeServerRestarting: ConnectionErrorType = 5;
+/**
+ * The host removed the player from the lobby, their ID is no longer valid
+ */
+export const ConnectionErrorTypeKicked: ConnectionErrorType = 6;
+/**
+ * The matchmaking ticket is unknown, expired or the queue shut down
+ */
+export const ConnectionErrorTypeTicketInvalid: ConnectionErrorType = 7;
 export interface ConnectionErrorMessage {
   base_message: BaseMessage;
   reason: string;
@@ -42,7 +84,68 @@ export interface ConnectionErrorMessage {
 export const MessageTypeGameState: MessageType = "game_state";
 export interface GameStateMessage {
   base_message: BaseMessage;
-  game_state: GameState;
+  game_state: PlayerView;
+  seq?: number /* uint64 */;
+}
+
+//////////
+// source: game_state_patch_message.go
+
+export const MessageTypeGameStatePatch: MessageType = "game_state_patch";
+/**
+ * GameStatePatchMessage carries the changes since the message with sequence
+ * number Seq-1. Clients that notice a gap should send a ResyncMessage.
+ */
+export interface GameStatePatchMessage {
+  base_message: BaseMessage;
+  seq: number /* uint64 */;
+  patch: PatchOperation[];
+}
+
+//////////
+// source: handshake_message.go
+
+export const MessageTypeHello: MessageType = "hello";
+export const MessageTypeWelcome: MessageType = "welcome";
+/**
+ * HelloMessage must be the first message a client sends after connecting
+ */
+export interface HelloMessage {
+  base_message: BaseMessage;
+  protocol_version: number /* int */;
+  features: Feature[];
+}
+/**
+ * WelcomeMessage answers a hello with the version and features in use for the connection
+ */
+export interface WelcomeMessage {
+  base_message: BaseMessage;
+  protocol_version: number /* int */;
+  features: Feature[];
+}
+
+//////////
+// source: lobby_list_message.go
+
+export const MessageTypeLobbyList: MessageType = "lobby_list";
+export interface LobbyListMessage {
+  base_message: BaseMessage;
+  games: LobbyListing[];
+}
+
+//////////
+// source: match_found_message.go
+
+export const MessageTypeMatchFound: MessageType = "match_found";
+/**
+ * MatchFoundMessage tells a queued player which game they were seated in. They
+ * join it like any other game with the IDs it carries.
+ */
+export interface MatchFoundMessage {
+  base_message: BaseMessage;
+  game_id: string;
+  player_id: string;
+  table_size: number /* int */;
 }
 
 //////////
@@ -53,4 +156,76 @@ export type Message = any;
 export interface BaseMessage {
   type: MessageType;
   sender_id: string;
+  /**
+   * RequestID is optionally set by clients on actions and echoed back on
+   * every reply to that action
+   */
+  request_id?: string;
+}
+
+//////////
+// source: msgpack.go
+
+
+//////////
+// source: patch.go
+
+export type PatchOp = string;
+export const PatchOpAdd: PatchOp = "add";
+export const PatchOpRemove: PatchOp = "remove";
+export const PatchOpReplace: PatchOp = "replace";
+/**
+ * PatchOperation is a single RFC 6902 JSON Patch operation
+ */
+export interface PatchOperation {
+  op: PatchOp;
+  path: string;
+  value?: any;
+}
+
+//////////
+// source: protocol.go
+
+/**
+ * ProtocolVersion is bumped whenever a change to the messages would break
+ * clients built against the previous version
+ */
+export const ProtocolVersion = 1;
+/**
+ * Feature is an optional protocol capability a client can ask for in its hello
+ */
+export type Feature = string;
+export const FeatureDeltaUpdates: Feature = "delta_updates";
+/**
+ * FeatureMsgPack switches every message after the welcome to binary
+ * MessagePack frames, in both directions
+ */
+export const FeatureMsgPack: Feature = "msgpack";
+/**
+ * Encoding is the wire format a connection uses once the handshake is done
+ */
+export type Encoding = string;
+export const EncodingJSON: Encoding = "json";
+export const EncodingMsgPack: Encoding = "msgpack";
+
+//////////
+// source: resync_message.go
+
+export const MessageTypeResync: MessageType = "resync";
+/**
+ * ResyncMessage asks the server for a full game state snapshot
+ */
+export interface ResyncMessage {
+  base_message: BaseMessage;
+}
+
+//////////
+// source: streamer_state_message.go
+
+export const MessageTypeStreamerState: MessageType = "streamer_state";
+export interface StreamerStateMessage {
+  base_message: BaseMessage;
+  game_state: StreamerView;
+  delay_seconds: number /* int */;
+  delay_rounds: number /* int */;
 }
@@ -17,6 +17,16 @@ export const ActionProposeVeto: Action = "propose_veto";
 export const ActionApproveVeto: Action = "approve_veto";
 export const ActionRejectVeto: Action = "reject_veto";
 export const ActionEndTurn: Action = "end_turn";
+export const ActionVoteContinue: Action = "vote_continue"; // Go on without players who left
+export const ActionAbortGame: Action = "abort_game";
+export const ActionKickPlayer: Action = "kick_player";
+export const ActionTransferHost: Action = "transfer_host";
+export const ActionLockLobby: Action = "lock_lobby";
+export const ActionUnlockLobby: Action = "unlock_lobby";
+export const ActionReorderSeats: Action = "reorder_seats";
+export const ActionReadyCheck: Action = "ready_check"; // Host asks everyone to confirm they're ready
+export const ActionReady: Action = "ready";
+export const ActionRematch: Action = "rematch"; // From the host starts one, from anyone else it's a vote
 export const ActionNone: Action = "none";
 
 //////////
@@ -47,6 +57,14 @@ export const CardHidden: Card = "hidden";
 //////////
 // source: game_state.go
 
+/**
+ * A table seats between MinPlayers and MaxPlayers players
+ */
+export const MinPlayers = 5;
+/**
+ * A table seats between MinPlayers and MaxPlayers players
+ */
+export const MaxPlayers = 10;
 export type VoteResult = number /* int */;
 export const VotePending: VoteResult = 0;
 export const VoteHidden: VoteResult = 1;
@@ -58,10 +76,17 @@ export interface ChatEntry {
   text: string;
   sent_at_unix: number /* int64 */;
 }
-export interface GameState {
-  players: Player[];
+/**
+ * PolicyPiles holds the real draw and discard piles. It never leaves the
+ * server, clients only ever see the pile sizes through views.PlayerView.
+ */
+export interface PolicyPiles {
   deck: Card[];
   discard: Card[];
+}
+export interface GameState {
+  players: Player[];
+  piles: PolicyPiles;
   board: Board;
   president_index: number /* int */;
   chancellor_index: number /* int */;
@@ -75,9 +100,62 @@ export interface GameState {
   peeker_index?: number /* int */;
   resume_order_index?: number /* int */; // Post special election
   resume_phase?: GamePhase;
+  pause?: PauseInfo;
   winner?: Team;
+  win_reason?: WinReason;
+  continue_votes?: VoteResult[]; // Vote to go on without missing players
   host_id: string;
+  lobby_locked: boolean;
+  ready_check?: ReadyCheckInfo;
   chat_history: ChatEntry[];
+  round: number /* int */;
+  history: RoundRecord[];
+  match_number: number /* int */; // Counts rematches at the same table, from 1
+  starting_president: number /* int */; // First president of this match, set up ahead of time for a rematch
+  rematch_votes?: VoteResult[];
+}
+
+//////////
+// source: history.go
+
+/**
+ * RoundRecord is what happened in a single election: who ran, how everyone
+ * voted, and what came of it
+ */
+export interface RoundRecord {
+  round: number /* int */;
+  president_index: number /* int */;
+  chancellor_index: number /* int */;
+  votes: VoteResult[];
+  elected: boolean;
+  enacted_policy?: Card;
+  chaos_policy?: boolean; // Enacted by the election tracker
+  executive_action?: Action;
+  action_target: number /* int */;
+}
+
+//////////
+// source: pause.go
+
+export type PauseReason = string;
+export const PauseReasonDisconnected: PauseReason = "player_disconnected";
+export const PauseReasonServerRestart: PauseReason = "server_restart";
+/**
+ * WaitingPlayer is a missing player the game is paused for. Once
+ * VoteOpensAtUnix has passed the table may vote to go on without them.
+ */
+export interface WaitingPlayer {
+  index: number /* int */;
+  disconnected_at_unix: number /* int64 */;
+  vote_opens_at_unix: number /* int64 */;
+}
+/**
+ * PauseInfo explains why a paused game is paused and who it is waiting for
+ */
+export interface PauseInfo {
+  reason: PauseReason;
+  since_unix: number /* int64 */;
+  waiting_for: WaitingPlayer[];
 }
 
 //////////
@@ -85,6 +163,7 @@ export interface GameState {
 
 export type GamePhase = string;
 export const Setup: GamePhase = "setup"; // TODO
+export const ReadyCheck: GamePhase = "ready_check";
 export const Nomination: GamePhase = "nomination";
 export const Election: GamePhase = "election";
 export const Legislation1: GamePhase = "legislation1";
@@ -108,6 +187,20 @@ export interface Player {
   role: PlayerRole;
   is_executed: boolean;
   is_connected: boolean;
+  is_abandoned: boolean; // Voted out by the table after leaving
+  is_ready: boolean;
+  disconnected_at_unix?: number /* int64 */;
+}
+
+//////////
+// source: ready.go
+
+/**
+ * ReadyCheckInfo tracks the ready check run before the game starts
+ */
+export interface ReadyCheckInfo {
+  started_at_unix: number /* int64 */;
+  deadline_unix: number /* int64 */; // Players not ready by then are removed
 }
 
 //////////
@@ -117,3 +210,19 @@ export type Team = string;
 export const TeamUnassigned: Team = "UNASSIGNED";
 export const TeamFascist: Team = "FASCISTS";
 export const TeamLiberal: Team = "LIBERALS";
+/**
+ * WinReason is how the game was decided
+ */
+export type WinReason = string;
+export const WinReasonNone: WinReason = "";
+export const WinReasonLiberalPolicies: WinReason = "liberal_policies";
+export const WinReasonFascistPolicies: WinReason = "fascist_policies";
+export const WinReasonHitlerExecuted: WinReason = "hitler_executed";
+export const WinReasonHitlerElected: WinReason = "hitler_elected";
+export const WinReasonAborted: WinReason = "aborted"; // Ended early by the host, nobody wins
+
+//////////
+// source: username.go
+
+export const MinUsernameLength = 2;
+export const MaxUsernameLength = 20;
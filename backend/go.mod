@@ -4,9 +4,11 @@ go 1.23.4
 
 require github.com/gorilla/websocket v1.5.3
 
-require github.com/go-chi/cors v1.2.2 // indirect
+require github.com/go-chi/cors v1.2.2
 
 require (
 	github.com/go-chi/chi v1.5.5
-	github.com/go-chi/chi/v5 v5.2.3 // indirect
+	github.com/go-chi/chi/v5 v5.2.3
 )
+
+require golang.org/x/text v0.21.0
@@ -19,3 +19,36 @@ func GetEnv() Env {
 	}
 	return Development
 }
+
+type StoreKind string
+
+const (
+	StoreMemory StoreKind = "memory"
+	StoreFile   StoreKind = "file"
+)
+
+// StoreConfig picks where games are persisted. The file store keeps games
+// across restarts, the memory store is handy for local development.
+type StoreConfig struct {
+	Kind StoreKind
+	Dir  string
+}
+
+func GetStoreConfig() StoreConfig {
+	config := StoreConfig{Kind: StoreFile, Dir: "data/games"}
+	if os.Getenv("GAME_STORE") == string(StoreMemory) {
+		config.Kind = StoreMemory
+	}
+	if dir := os.Getenv("GAME_STORE_DIR"); dir != "" {
+		config.Dir = dir
+	}
+	return config
+}
+
+// GetClientIPHeader names the header the proxy in front of us fills in with the
+// client's address, like Fly-Client-IP on Fly. Clients can send any header
+// they like, so without a proxy that overwrites it this must stay empty and
+// the peer address is used instead.
+func GetClientIPHeader() string {
+	return os.Getenv("CLIENT_IP_HEADER")
+}
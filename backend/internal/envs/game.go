@@ -0,0 +1,38 @@
+package envs
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// PausePolicy decides which disconnects pause a game in progress
+type PausePolicy string
+
+const (
+	PauseAlways     PausePolicy = "always"      // Any missing player pauses the game
+	PauseWhenNeeded PausePolicy = "when_needed" // Only a missing player whose turn or vote it is
+)
+
+// GameConfig holds the rules around players leaving mid-game
+type GameConfig struct {
+	AbandonGrace time.Duration // How long a player has to reconnect before the table can vote to go on
+	PausePolicy  PausePolicy
+	ReadyTimeout time.Duration // How long players have to confirm a ready check
+	LobbyGrace   time.Duration // How long a player who left the lobby keeps their seat
+}
+
+func GetGameConfig() GameConfig {
+	config := GameConfig{
+		AbandonGrace: getDurationEnv("GAME_ABANDON_GRACE", 3*time.Minute),
+		PausePolicy:  PauseWhenNeeded,
+		ReadyTimeout: getDurationEnv("GAME_READY_TIMEOUT", time.Minute),
+		LobbyGrace:   getDurationEnv("GAME_LOBBY_GRACE", 30*time.Second),
+	}
+	if os.Getenv("GAME_PAUSE_POLICY") == string(PauseAlways) {
+		config.PausePolicy = PauseAlways
+	}
+
+	fmt.Println("Abandon grace period:", config.AbandonGrace, "lobby grace period:", config.LobbyGrace, "pause policy:", config.PausePolicy)
+	return config
+}
@@ -0,0 +1,37 @@
+package envs
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// JanitorConfig controls how long idle games are kept before they are reaped
+type JanitorConfig struct {
+	Interval    time.Duration
+	SetupTTL    time.Duration // Lobby with nobody connected
+	PausedTTL   time.Duration // Game waiting on a player who never came back
+	GameOverTTL time.Duration // Finished game with nobody connected
+}
+
+// GetJanitorConfig reads the reaper settings from the environment. Values are
+// Go durations such as "10m" or "1h30m", unset or invalid ones use the defaults.
+func GetJanitorConfig() JanitorConfig {
+	config := JanitorConfig{
+		Interval:    getDurationEnv("GAME_REAP_INTERVAL", time.Minute),
+		SetupTTL:    getDurationEnv("GAME_TTL_SETUP", 10*time.Minute),
+		PausedTTL:   getDurationEnv("GAME_TTL_PAUSED", 30*time.Minute),
+		GameOverTTL: getDurationEnv("GAME_TTL_GAME_OVER", 10*time.Minute),
+	}
+
+	fmt.Println("Game TTLs: setup", config.SetupTTL, "paused", config.PausedTTL, "game over", config.GameOverTTL)
+	return config
+}
+
+func getDurationEnv(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,26 @@
+package envs
+
+import (
+	"fmt"
+	"time"
+)
+
+// MatchmakingConfig controls how the quick play queue groups players
+type MatchmakingConfig struct {
+	Interval      time.Duration
+	FallbackAfter time.Duration // Wait after which a player takes any table from 5 up to their preferred size
+	TicketTTL     time.Duration // Wait after which a player is taken out of the queue
+}
+
+// GetMatchmakingConfig reads the queue settings from the environment, with the
+// same duration format as the janitor settings
+func GetMatchmakingConfig() MatchmakingConfig {
+	config := MatchmakingConfig{
+		Interval:      getDurationEnv("MATCHMAKING_INTERVAL", time.Second),
+		FallbackAfter: getDurationEnv("MATCHMAKING_FALLBACK_AFTER", 30*time.Second),
+		TicketTTL:     getDurationEnv("MATCHMAKING_TICKET_TTL", 5*time.Minute),
+	}
+
+	fmt.Println("Matchmaking: fallback after", config.FallbackAfter, "tickets expire after", config.TicketTTL)
+	return config
+}
@@ -0,0 +1,55 @@
+package envs
+
+import (
+	"compress/flate"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+type WebsocketConfig struct {
+	ReadBufferSize   int
+	WriteBufferSize  int
+	Compression      bool
+	CompressionLevel int
+	ReadLimit        int64 // Largest frame accepted from a client, in bytes
+}
+
+const defaultReadLimit = 64 * 1024
+
+// GetWebsocketConfig reads the websocket settings from the environment. Unset
+// or invalid values fall back to the defaults.
+func GetWebsocketConfig() WebsocketConfig {
+	config := WebsocketConfig{
+		ReadBufferSize:   getIntEnv("WS_READ_BUFFER_SIZE", 1024),
+		WriteBufferSize:  getIntEnv("WS_WRITE_BUFFER_SIZE", 1024),
+		Compression:      os.Getenv("WS_COMPRESSION") == "true",
+		CompressionLevel: getIntEnv("WS_COMPRESSION_LEVEL", flate.BestSpeed),
+		ReadLimit:        int64(getIntEnv("WS_READ_LIMIT", defaultReadLimit)),
+	}
+
+	if config.ReadBufferSize <= 0 {
+		config.ReadBufferSize = 1024
+	}
+	if config.WriteBufferSize <= 0 {
+		config.WriteBufferSize = 1024
+	}
+	if config.ReadLimit <= 0 {
+		config.ReadLimit = defaultReadLimit
+	}
+	// Anything outside of what permessage-deflate accepts is ignored
+	if config.CompressionLevel < flate.HuffmanOnly || config.CompressionLevel > flate.BestCompression {
+		config.CompressionLevel = flate.BestSpeed
+	}
+
+	fmt.Println("Websocket compression:", config.Compression, "level:", config.CompressionLevel)
+	return config
+}
+
+func getIntEnv(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,152 @@
+package game
+
+import (
+	"slices"
+	"time"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/messages"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/models"
+)
+
+// voteContinue records a vote to go on without the players who have been gone
+// longer than the grace period, and leaves them behind once most of the
+// players still at the table agree
+func (g *Game) voteContinue(message messages.ActionMessage) *messages.ActionErrorMessage {
+	if errorMessage := g.requirePhase(message, models.Paused); errorMessage != nil {
+		return errorMessage
+	}
+
+	if message.Vote == nil {
+		return actionError(message, messages.ErrorCodeMissingVote, nil)
+	}
+
+	now := time.Now()
+	if len(g.state.AbandonCandidates(now)) == 0 {
+		return actionError(message, messages.ErrorCodeGracePeriod, messages.ActionErrorParams{
+			"remaining_seconds": g.graceRemaining(now),
+		})
+	}
+
+	voterIndex := g.state.PlayerIndexMap[message.SenderID]
+	if g.state.ContinueVotes == nil {
+		g.state.ContinueVotes = make([]models.VoteResult, len(g.state.Players))
+	}
+	if g.state.ContinueVotes[voterIndex] != models.VotePending {
+		return actionError(message, messages.ErrorCodeAlreadyVoted, nil)
+	}
+
+	if *message.Vote {
+		g.state.ContinueVotes[voterIndex] = models.VoteJa
+	} else {
+		g.state.ContinueVotes[voterIndex] = models.VoteNein
+	}
+
+	eligibleVoters := 0
+	yesVotes := 0
+	pendingVotes := 0
+	for i, player := range g.state.Players {
+		if !player.IsConnected || player.IsExecuted {
+			continue
+		}
+		eligibleVoters++
+		switch g.state.ContinueVotes[i] {
+		case models.VoteJa:
+			yesVotes++
+		case models.VotePending:
+			pendingVotes++
+		}
+	}
+
+	switch {
+	case yesVotes > eligibleVoters/2:
+		g.abandonMissingPlayers(g.state.AbandonCandidates(now))
+	case yesVotes+pendingVotes <= eligibleVoters/2:
+		// The vote can't pass anymore, the table keeps waiting
+		g.state.ContinueVotes = nil
+	}
+
+	return nil
+}
+
+// graceRemaining is how many seconds until the first missing player can be
+// voted out
+func (g *Game) graceRemaining(now time.Time) int64 {
+	if g.state.Pause == nil || len(g.state.Pause.WaitingFor) == 0 {
+		return 0
+	}
+	opensAt := g.state.Pause.WaitingFor[0].VoteOpensAtUnix
+	for _, waiting := range g.state.Pause.WaitingFor {
+		opensAt = min(opensAt, waiting.VoteOpensAtUnix)
+	}
+	return max(opensAt-now.Unix(), 0)
+}
+
+// abandonMissingPlayers leaves the given players behind and resumes the game,
+// moving past whatever the game was waiting on them for. The game stays paused
+// if anyone else is still within their grace period.
+func (g *Game) abandonMissingPlayers(indexes []int) {
+	for _, i := range indexes {
+		g.state.AbandonPlayer(i)
+	}
+	g.state.ContinueVotes = nil
+	g.state.Pause = nil
+	g.state.Phase = g.state.ResumePhase
+	g.state.ResumePhase = ""
+
+	isAbandoned := func(index int) bool {
+		return slices.Contains(indexes, index)
+	}
+
+	// Someone still at the table needs to be able to abort the game
+	if hostIndex, exists := g.state.PlayerIndexMap[g.HostID]; exists && isAbandoned(hostIndex) {
+		for _, player := range g.state.Players {
+			if player.IsConnected && !player.IsAbandoned {
+				g.SetHostID(player.ID)
+				break
+			}
+		}
+	}
+
+	switch g.state.Phase {
+	case models.Nomination, models.Executive:
+		if isAbandoned(g.state.PresidentIndex) {
+			g.state.NewTurn()
+		}
+	case models.Election:
+		if isAbandoned(g.state.PresidentIndex) || isAbandoned(g.state.NomineeIndex) {
+			g.state.NewTurn()
+		} else {
+			// The abandoned players may have been the last votes missing
+			g.tallyVotes()
+		}
+	case models.Legislation1, models.Legislation2:
+		if isAbandoned(g.state.PresidentIndex) || isAbandoned(g.state.ChancellorIndex) {
+			// The government falls, the undecided policies go back on the deck
+			g.state.Deck = append(slices.Clone(g.state.PeekedCards), g.state.Deck...)
+			g.state.NewTurn()
+		}
+	}
+
+	if !g.state.EndGameIfNecessary() {
+		g.updatePause(models.PauseReasonDisconnected)
+	}
+}
+
+// abortGame ends the game early on the host's say, nobody wins
+func (g *Game) abortGame(message messages.ActionMessage) *messages.ActionErrorMessage {
+	if message.SenderID != g.HostID {
+		return actionError(message, messages.ErrorCodeNotHost, nil)
+	}
+
+	if g.state.InLobby() || g.state.Phase == models.GameOver {
+		return actionError(message, messages.ErrorCodeWrongPhase, messages.ActionErrorParams{
+			"actual": g.state.Phase,
+		})
+	}
+
+	g.state.ContinueVotes = nil
+	g.state.Pause = nil
+	g.state.ResumePhase = ""
+	g.state.EndGame(models.TeamUnassigned, models.WinReasonAborted)
+	return nil
+}
@@ -0,0 +1,136 @@
+package game
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/models"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/repository"
+)
+
+// AccessOptions restrict who may join a game
+type AccessOptions struct {
+	Password   string
+	InviteOnly bool
+}
+
+// Access is what a game checks joins against. The password is only kept
+// salted and hashed, a plain hash is plenty for a password that lives as long
+// as a single game.
+type Access struct {
+	Salt         []byte          `json:"salt,omitempty"`
+	PasswordHash []byte          `json:"password_hash,omitempty"`
+	InviteOnly   bool            `json:"invite_only"`
+	Invites      map[string]bool `json:"invites,omitempty"` // Unused invite tokens
+}
+
+func hashPassword(salt []byte, password string) []byte {
+	sum := sha256.Sum256(append(append([]byte{}, salt...), password...))
+	return sum[:]
+}
+
+func randomToken(bytes int) string {
+	b := make([]byte, bytes)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func (g *Game) SetAccess(opts AccessOptions) {
+	g.accessMu.Lock()
+	g.access = Access{
+		InviteOnly: opts.InviteOnly,
+		Invites:    make(map[string]bool),
+	}
+	if opts.Password != "" {
+		g.access.Salt = []byte(randomToken(16))
+		g.access.PasswordHash = hashPassword(g.access.Salt, opts.Password)
+	}
+	g.accessMu.Unlock()
+
+	// Invite-only games drop out of the lobby browser
+	g.connMu.RLock()
+	defer g.connMu.RUnlock()
+	g.refreshListing()
+}
+
+func (g *Game) IsPasswordProtected() bool {
+	g.accessMu.Lock()
+	defer g.accessMu.Unlock()
+	return g.access.PasswordHash != nil
+}
+
+func (g *Game) IsInviteOnly() bool {
+	g.accessMu.Lock()
+	defer g.accessMu.Unlock()
+	return g.access.InviteOnly
+}
+
+// NewInvite creates a single use invite token. It lets one player in without
+// the password, and is the only way into an invite-only game.
+func (g *Game) NewInvite() string {
+	g.accessMu.Lock()
+	defer g.accessMu.Unlock()
+
+	token := randomToken(18)
+	if g.access.Invites == nil {
+		g.access.Invites = make(map[string]bool)
+	}
+	g.access.Invites[token] = true
+	return token
+}
+
+// NewInviteFor creates an invite on behalf of a player, who must be the host
+func (g *Game) NewInviteFor(playerID string) (string, error) {
+	g.connMu.RLock()
+	isHost := playerID != "" && playerID == g.HostID
+	g.connMu.RUnlock()
+
+	if !isHost {
+		return "", repository.ErrNotHost
+	}
+	return g.NewInvite(), nil
+}
+
+// Join adds a player after checking the password or invite. An invite is used
+// up only once the player is actually in.
+func (g *Game) Join(username string, password string, invite string) (*models.Player, error) {
+	g.accessMu.Lock()
+	usedInvite := invite != "" && g.access.Invites[invite]
+	switch {
+	case usedInvite:
+		delete(g.access.Invites, invite)
+	case invite != "":
+		g.accessMu.Unlock()
+		return nil, repository.ErrInvalidInvite
+	case g.access.InviteOnly:
+		g.accessMu.Unlock()
+		return nil, repository.ErrInviteRequired
+	case g.access.PasswordHash != nil && subtle.ConstantTimeCompare(hashPassword(g.access.Salt, password), g.access.PasswordHash) != 1:
+		g.accessMu.Unlock()
+		return nil, repository.ErrWrongPassword
+	}
+	g.accessMu.Unlock()
+
+	player, err := g.NewPlayer(username)
+	if err != nil && usedInvite {
+		// The invite wasn't what stopped them, so they can try again with it
+		g.accessMu.Lock()
+		g.access.Invites[invite] = true
+		g.accessMu.Unlock()
+	}
+	return player, err
+}
+
+func (g *Game) accessSnapshot() Access {
+	g.accessMu.Lock()
+	defer g.accessMu.Unlock()
+
+	access := g.access
+	access.Invites = make(map[string]bool, len(g.access.Invites))
+	for token := range g.access.Invites {
+		access.Invites[token] = true
+	}
+	return access
+}
@@ -0,0 +1,45 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/models"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/views"
+)
+
+// archiveIfOver writes the post-game summary once the game has been decided.
+// Only called from Run, between actions.
+func (g *Game) archiveIfOver() {
+	if g.archived || g.state.Phase != models.GameOver {
+		return
+	}
+
+	summary := views.ForSummary(g.ID, &g.state, time.Now())
+	data, err := json.Marshal(summary)
+	if err != nil {
+		fmt.Println("Failed to encode summary for game", g.ID, err)
+		return
+	}
+	if err := g.manager.store.SaveArchive(archiveID(g.ID, g.state.MatchNumber), data); err != nil {
+		fmt.Println("Failed to archive game", g.ID, err)
+		return
+	}
+	g.archived = true
+}
+
+// Summary returns the archived post-game summary of a finished game, which
+// stays available after the live game has been removed. Rematches at the same
+// table are told apart by their match number, starting from 1.
+func (m *Manager) Summary(id string, match int) (json.RawMessage, error) {
+	return m.store.LoadArchive(archiveID(id, match))
+}
+
+// archiveID keeps the first match under the plain game ID, as it was before rematches
+func archiveID(gameID string, match int) string {
+	if match <= 1 {
+		return gameID
+	}
+	return fmt.Sprintf("%s-%d", gameID, match)
+}
@@ -0,0 +1,12 @@
+package game
+
+import "github.com/VincentZhao12/secret-hitler/backend/internal/messages"
+
+// Client is a player's open connection to the game, whatever transport and
+// encoding it uses
+type Client interface {
+	// Send writes a message and returns how many bytes it took on the wire
+	Send(message messages.Message) (int, error)
+	// Close hangs up on the player, e.g. once they have been kicked
+	Close() error
+}
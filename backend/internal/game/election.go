@@ -0,0 +1,65 @@
+package game
+
+import (
+	"github.com/VincentZhao12/secret-hitler/backend/internal/models"
+)
+
+// tallyVotes settles the election once every living player has voted
+func (g *Game) tallyVotes() {
+	eligibleVoters := 0
+	votes := 0
+	yesVotes := 0
+	for i, vote := range g.state.Votes {
+		if g.state.Players[i].IsExecuted {
+			continue
+		}
+		eligibleVoters++
+		if vote != models.VotePending {
+			votes++
+		}
+		if vote == models.VoteJa {
+			yesVotes++
+		}
+	}
+
+	if votes != eligibleVoters {
+		return
+	}
+
+	g.state.RecordElection(yesVotes > eligibleVoters/2)
+	if yesVotes > eligibleVoters/2 {
+		g.state.Board.ElectionTracker.FailedElections = 0
+		g.state.ChancellorIndex = g.state.NomineeIndex
+		g.state.Phase = models.Legislation1
+		g.state.PeekerIndex = g.state.PresidentIndex
+
+		// Draw 3 cards
+		if len(g.state.Deck) < 3 {
+			g.state.ShuffleDeck()
+		}
+		g.state.PeekedCards = g.state.Deck[:3]
+		g.state.Deck = g.state.Deck[3:]
+		if g.state.Players[g.state.ChancellorIndex].Role == models.RoleHitler && g.state.Board.FascistPolicies >= g.state.Board.DangerZoneStart {
+			g.state.EndGame(models.TeamFascist, models.WinReasonHitlerElected)
+		}
+		return
+	}
+
+	g.state.Board.ElectionTracker.FailedElections++
+
+	if g.state.Board.ElectionTracker.FailedElections == g.state.Board.ElectionTracker.MaxFailures {
+		g.state.Board.ElectionTracker.FailedElections = 0
+		// Draw top policy
+		if len(g.state.Deck) < 1 {
+			g.state.ShuffleDeck()
+		}
+		topCard := g.state.Deck[0]
+		g.state.Deck = g.state.Deck[1:]
+
+		g.state.RecordPolicy(topCard, true)
+		g.PlaceCard(topCard)
+		return
+	}
+
+	g.state.NewTurn()
+}
@@ -10,17 +10,44 @@ import (
 	"github.com/VincentZhao12/secret-hitler/backend/internal/messages"
 	"github.com/VincentZhao12/secret-hitler/backend/internal/models"
 	"github.com/VincentZhao12/secret-hitler/backend/internal/repository"
-	"github.com/gorilla/websocket"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/views"
 )
 
 type Game struct {
-	state       models.GameState
-	ID          string
-	HostID      string
-	ActionChan  chan (messages.ActionMessage)
-	Connections map[string]*websocket.Conn
-	manager     *Manager
-	connMu      sync.RWMutex
+	state         models.GameState
+	ID            string
+	HostID        string
+	ActionChan    chan (messages.ActionMessage)
+	Connections   map[string]Client
+	manager       *Manager
+	connMu        sync.RWMutex
+	streamer      StreamerOptions
+	history       []stateSnapshot
+	historySeq    int
+	historyMu     sync.Mutex
+	streams       map[string]*stateStream
+	sendMu        sync.Mutex
+	requests      map[string]*requestLog
+	metrics       *TrafficMetrics
+	snapshotReq   chan chan GameSnapshot
+	joinReq       chan joinRequest
+	archived      bool
+	readyCheckSeq int
+	readyExpired  chan int
+	leaveSeq      int
+	pendingLeaves map[string]int // Lobby players waiting out their grace period, guarded by connMu
+	leaveExpired  chan pendingLeave
+	access        Access
+	accessMu      sync.Mutex
+	done          chan struct{}
+	closeOnce     sync.Once
+	persister     *persister
+
+	activityMu    sync.Mutex
+	lastActivity  time.Time
+	activityPhase models.GamePhase
+	visibility    Visibility
+	createdAt     time.Time
 }
 
 const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
@@ -38,13 +65,31 @@ func generateRandomID(length int) string {
 }
 
 func NewGame(manager *Manager) *Game {
+	return newGame(manager, generateRandomID(8), models.NewGameState())
+}
+
+func newGame(manager *Manager, id string, state models.GameState) *Game {
 	g := &Game{
-		ID:          generateRandomID(8),
-		state:       models.NewGameState(),
-		manager:     manager,
-		Connections: make(map[string]*websocket.Conn),
-		ActionChan:  make(chan messages.ActionMessage),
+		ID:            id,
+		state:         state,
+		HostID:        state.HostID,
+		manager:       manager,
+		Connections:   make(map[string]Client),
+		ActionChan:    make(chan messages.ActionMessage),
+		streams:       make(map[string]*stateStream),
+		requests:      make(map[string]*requestLog),
+		metrics:       NewTrafficMetrics(),
+		snapshotReq:   make(chan chan GameSnapshot),
+		joinReq:       make(chan joinRequest),
+		done:          make(chan struct{}),
+		readyExpired:  make(chan int),
+		pendingLeaves: make(map[string]int),
+		leaveExpired:  make(chan pendingLeave),
+		visibility:    VisibilityPrivate,
+		createdAt:     time.Now(),
+		persister:     newPersister(manager.store, id),
 	}
+	g.touch()
 	go g.Run()
 	return g
 }
@@ -54,7 +99,7 @@ func (g *Game) SetHostID(id string) {
 	g.state.HostID = id
 }
 
-func (g *Game) AddConnection(id string, conn *websocket.Conn) error {
+func (g *Game) AddConnection(id string, conn Client, opts ConnectionOptions) error {
 	g.connMu.Lock()
 	playerIndex, exists := g.state.PlayerIndexMap[id]
 	if !exists {
@@ -63,107 +108,137 @@ func (g *Game) AddConnection(id string, conn *websocket.Conn) error {
 		return repository.ErrPlayerNotFound
 	}
 
-	if oldConn, exists := g.Connections[id]; exists && oldConn != nil {
-		fmt.Println("player connection alr exist")
-		// return repository.ErrPlayerAlreadyExists
+	// A refresh can arrive before the old socket notices it is gone. The new
+	// connection takes over and the old one is hung up below.
+	oldConn := g.Connections[id]
+	if oldConn == conn {
+		oldConn = nil
 	}
 
 	player := g.state.GetPlayer(playerIndex)
 	if player != nil {
 		player.IsConnected = true
+		player.DisconnectedAtUnix = 0
 	}
 	g.Connections[id] = conn
+	g.cancelRemovePlayer(id)
 
-	if len(g.Connections) == len(g.state.Players) && g.state.Phase == models.Paused {
-		g.state.Phase = g.state.ResumePhase
-		g.state.ResumePhase = ""
-	}
+	g.updatePause(models.PauseReasonDisconnected)
 	playerForState := g.state.GetPlayerByID(id)
+	g.touch()
 	g.connMu.Unlock()
 
+	if oldConn != nil {
+		oldConn.Close()
+	}
+
+	// A new connection starts from a full snapshot whatever the old one was sent
+	g.resetStream(id, opts)
 	if playerForState != nil {
-		conn.WriteJSON(messages.NewGameStateMessage(
-			"server",
-			g.state.ObfuscateGameState(*playerForState),
-		))
+		g.sendState(id, conn, views.ForPlayer(&g.state, id), "")
 	}
 	g.broadcastGameState()
 
 	return nil
 }
 
-func (g *Game) CanBeDeleted() bool {
-	return len(g.Connections) == 0 && (g.state.Phase == models.GameOver || g.state.Phase == models.Setup)
-}
-
-func (g *Game) scheduleRemovePlayer(id string) {
+func (g *Game) HasPlayer(id string) bool {
+	g.connMu.RLock()
+	defer g.connMu.RUnlock()
+	_, exists := g.state.PlayerIndexMap[id]
+	return exists
 }
 
-func (g *Game) DropConnection(id string) error {
+// DropConnection marks the player as gone once conn closes. A connection that
+// was already replaced by a newer one is ignored, so a late close can't
+// disconnect a player who has just reconnected.
+func (g *Game) DropConnection(id string, conn Client) error {
 	g.connMu.Lock()
 	playerIndex, exists := g.state.PlayerIndexMap[id]
 	if !exists {
 		g.connMu.Unlock()
 		return repository.ErrPlayerNotFound
 	}
+	if g.Connections[id] != conn {
+		g.connMu.Unlock()
+		return nil
+	}
 
 	player := g.state.GetPlayer(playerIndex)
 	if player != nil {
 		player.IsConnected = false
+		player.DisconnectedAtUnix = time.Now().Unix()
 	}
 
 	delete(g.Connections, id)
-	if g.state.Phase != models.GameOver && g.state.Phase != models.Setup {
-		g.state.ResumePhase = g.state.Phase
-		g.state.Phase = models.Paused
-	}
+	g.updatePause(models.PauseReasonDisconnected)
 
-	if g.state.Phase == models.Setup {
-		err := g.state.RemovePlayer(player.ID)
-		if err != nil {
-			g.connMu.Unlock()
-			return err
-		}
-		if len(g.state.Players) == 0 {
-			g.connMu.Unlock()
-			return nil
-		}
-		fmt.Println(g.HostID, id)
-		if g.HostID == id {
-			g.SetHostID(g.state.Players[0].ID)
-		}
+	// A page refresh in the lobby shouldn't cost anyone their seat
+	if g.state.InLobby() {
+		g.scheduleRemovePlayer(id)
 	}
+	g.touch()
 	g.connMu.Unlock()
 	g.broadcastGameState()
 
 	return nil
 }
 
-func (g *Game) EndGame(winner models.Team) {
-	g.state.EndGame(winner)
-	close(g.ActionChan)
+func (g *Game) EndGame(winner models.Team, reason models.WinReason) {
+	g.state.EndGame(winner, reason)
+}
+
+// joinRequest asks the game loop to seat a new player
+type joinRequest struct {
+	username string
+	reply    chan joinResult
+}
+
+type joinResult struct {
+	player *models.Player
+	err    error
 }
 
+// NewPlayer seats a new player. Kicks, seat changes and expired grace periods
+// all happen in Run, so the join is made there too rather than racing them.
 func (g *Game) NewPlayer(username string) (*models.Player, error) {
-	playerID := generateRandomID(16)
-	player, err := g.state.AddPlayer(playerID, username)
-	if err != nil {
-		return nil, err
+	reply := make(chan joinResult, 1)
+	select {
+	case g.joinReq <- joinRequest{username: username, reply: reply}:
+	case <-g.done:
+		return nil, repository.ErrGameClosed
 	}
+	result := <-reply
+	return result.player, result.err
+}
 
+// addPlayer seats a player for NewPlayer. Only called from Run.
+func (g *Game) addPlayer(username string) (*models.Player, error) {
+	playerID := generateRandomID(16)
+
+	g.connMu.Lock()
+	player, err := g.state.AddPlayer(playerID, username)
 	// Set host to first player if not already set
-	if g.HostID == "" {
+	if err == nil && g.HostID == "" {
 		g.SetHostID(playerID)
 	}
+	g.connMu.Unlock()
+	if err != nil {
+		return nil, err
+	}
 
+	g.touch()
 	g.broadcastGameState()
+	g.persist(EventPlayerJoined, map[string]string{"username": player.Username}, g.takeSnapshot())
 
 	return player, nil
 }
 
 func (g *Game) broadcastGameState() {
+	g.recordSnapshot()
+
 	g.connMu.RLock()
-	snapshot := make(map[string]*websocket.Conn, len(g.Connections))
+	snapshot := make(map[string]Client, len(g.Connections))
 	for id, conn := range g.Connections {
 		snapshot[id] = conn
 	}
@@ -173,14 +248,7 @@ func (g *Game) broadcastGameState() {
 		if conn != nil {
 			player := g.state.GetPlayerByID(id)
 			if player != nil {
-				gameState := g.state
-				if gameState.Phase != models.GameOver {
-					gameState = gameState.ObfuscateGameState(*player)
-				}
-				if err := conn.WriteJSON(messages.NewGameStateMessage(
-					"server",
-					gameState,
-				)); err != nil {
+				if err := g.sendState(id, conn, views.ForPlayer(&g.state, id), ""); err != nil {
 					println("error sending message")
 				}
 			}
@@ -188,27 +256,58 @@ func (g *Game) broadcastGameState() {
 	}
 }
 
-func (g *Game) validateActionMessage(message messages.ActionMessage, requiresPresident bool, requiresTarget bool) *messages.ActionErrorMessage {
-	president := g.state.GetPlayer(g.state.PresidentIndex)
-	if requiresPresident && (president == nil || president.ID != message.SenderID) {
-		return messages.NewActionErrorMessage(message.SenderID, messages.NotAllowed)
+func (g *Game) broadcastRestart() {
+	g.connMu.RLock()
+	snapshot := make(map[string]Client, len(g.Connections))
+	for id, conn := range g.Connections {
+		snapshot[id] = conn
 	}
+	g.connMu.RUnlock()
 
-	if requiresTarget && (message.TargetIndex < 0 || message.TargetIndex >= len(g.state.Players)) {
-		return messages.NewActionErrorMessage(message.SenderID, messages.InvalidTarget)
+	for _, conn := range snapshot {
+		if conn != nil {
+			g.sendMessage(conn, messages.NewConnectionErrorMessage(
+				"server",
+				"The server is restarting, you will be reconnected shortly",
+				messages.ConnectionErrorTypeServerRestarting,
+			))
+		}
 	}
+}
 
-	targetPlayer := g.state.GetPlayer(message.TargetIndex)
-	if requiresTarget && (targetPlayer == nil || targetPlayer.IsExecuted) {
-		return messages.NewActionErrorMessage(message.SenderID, messages.InvalidTarget)
+// Submit hands an action to the game loop, failing once the game is closed
+func (g *Game) Submit(action messages.ActionMessage) error {
+	select {
+	case g.ActionChan <- action:
+		return nil
+	case <-g.done:
+		return repository.ErrGameClosed
 	}
+}
 
-	sender := g.state.GetPlayerByID(message.SenderID)
-	if sender != nil && sender.IsExecuted {
-		return messages.NewActionErrorMessage(message.SenderID, messages.NotAllowed)
-	}
+// Close stops the game loop and tells everyone still connected that the game
+// is gone. Further actions are refused.
+func (g *Game) Close() {
+	g.closeOnce.Do(func() {
+		close(g.done)
 
-	return nil
+		g.connMu.RLock()
+		snapshot := make(map[string]Client, len(g.Connections))
+		for id, conn := range g.Connections {
+			snapshot[id] = conn
+		}
+		g.connMu.RUnlock()
+
+		for _, conn := range snapshot {
+			if conn != nil {
+				g.sendMessage(conn, messages.NewConnectionErrorMessage(
+					"server",
+					"This game was closed after being idle for too long",
+					messages.ConnectionErrorTypeGameInvalid,
+				))
+			}
+		}
+	})
 }
 
 func (g *Game) NewTurn() {
@@ -222,11 +321,77 @@ func (g *Game) PlaceCard(card models.Card) bool {
 
 func (g *Game) Run() {
 	for {
-		message, ok := <-g.ActionChan
-		if !ok {
+		var message messages.ActionMessage
+		select {
+		case <-g.done:
 			return
+		case seq := <-g.readyExpired:
+			g.expireReadyCheck(seq)
+			g.touch()
+			continue
+		case leave := <-g.leaveExpired:
+			g.removeAfterGrace(leave)
+			g.touch()
+			continue
+		case join := <-g.joinReq:
+			player, err := g.addPlayer(join.username)
+			join.reply <- joinResult{player: player, err: err}
+			continue
+		case reply := <-g.snapshotReq:
+			// Taken here so a snapshot never sees an action half applied
+			reply <- g.takeSnapshot()
+			continue
+		case action, ok := <-g.ActionChan:
+			if !ok {
+				return
+			}
+			message = action
 		}
-		response := g.ProcessActionMessage(message)
+
+		var response messages.Message
+		applied := true
+		if message.RequestID == "" {
+			response = g.ProcessActionMessage(message)
+		} else {
+			// A retried action isn't applied again. It gets the original error, or
+			// if it went through, the state as it is now. An investigation shows
+			// the original result again, whatever target the retry names.
+			log := g.requestLogFor(message.SenderID)
+			outcome, seen := log.get(message.RequestID)
+			switch {
+			case seen && outcome.failure != nil:
+				response = outcome.failure
+				applied = false
+			case seen && outcome.investigatedIndex >= 0:
+				response = messages.NewGameStateMessage("server", views.WithInvestigation(&g.state, message.SenderID, outcome.investigatedIndex))
+				response.SetRequestID(message.RequestID)
+				applied = false
+			case seen:
+				response = messages.NewGameStateMessage("server", views.ForPlayer(&g.state, message.SenderID))
+				response.SetRequestID(message.RequestID)
+				applied = false
+			default:
+				response = g.ProcessActionMessage(message)
+				response.SetRequestID(message.RequestID)
+				outcome := requestOutcome{investigatedIndex: -1}
+				outcome.failure, _ = response.(*messages.ActionErrorMessage)
+				if outcome.failure == nil && message.Action == models.ActionInvestigate {
+					outcome.investigatedIndex = message.TargetIndex
+				}
+				log.remember(message.RequestID, outcome)
+			}
+		}
+
+		if _, failed := response.(*messages.ActionErrorMessage); applied && !failed {
+			// The action may have handed the turn to someone who isn't here
+			if g.updatePause(models.PauseReasonDisconnected) {
+				g.broadcastGameState()
+			}
+			g.touch()
+			g.archiveIfOver()
+			g.persist(EventActionApplied, message, g.takeSnapshot())
+		}
+
 		g.connMu.RLock()
 		conn, exists := g.Connections[message.SenderID]
 		g.connMu.RUnlock()
@@ -235,7 +400,13 @@ func (g *Game) Run() {
 			continue
 		}
 
-		if err := conn.WriteJSON(response); err != nil {
+		var err error
+		if stateMessage, ok := response.(*messages.GameStateMessage); ok {
+			err = g.sendState(message.SenderID, conn, stateMessage.GameState, message.RequestID)
+		} else {
+			err = g.sendMessage(conn, response)
+		}
+		if err != nil {
 			println("error writing")
 			continue
 		}
@@ -246,13 +417,15 @@ func (g *Game) Run() {
 func (g *Game) ProcessActionMessage(message messages.ActionMessage) messages.Message {
 	p := g.state.GetPlayerByID(message.SenderID)
 	if p == nil {
-		return messages.NewActionErrorMessage(message.SenderID, messages.NotAllowed)
+		return actionError(message, messages.ErrorCodeUnknownPlayer, nil)
 	}
 	switch message.Action {
 	case models.ActionChatSend:
 		trimmedText := strings.TrimSpace(message.Text)
 		if trimmedText == "" || len(trimmedText) > maxChatLength {
-			return messages.NewActionErrorMessage(message.SenderID, messages.InvalidAction(message.Action))
+			return actionError(message, messages.ErrorCodeInvalidChat, messages.ActionErrorParams{
+				"max_length": maxChatLength,
+			})
 		}
 
 		g.state.ChatHistory = append(g.state.ChatHistory, models.ChatEntry{
@@ -269,25 +442,47 @@ func (g *Game) ProcessActionMessage(message messages.ActionMessage) messages.Mes
 
 		return messages.NewGameStateMessage(
 			"server",
-			g.state.ObfuscateGameState(*p),
+			views.ForPlayer(&g.state, p.ID),
 		)
 
 	case models.ActionStartGame:
 		// Only host can start the game
 		if message.SenderID != g.HostID {
-			return messages.NewActionErrorMessage(message.SenderID, messages.NotAllowed)
+			return actionError(message, messages.ErrorCodeNotHost, nil)
+		}
+
+		if errorMessage := g.requirePhase(message, models.ReadyCheck); errorMessage != nil {
+			return errorMessage
 		}
 
+		if !g.state.AllReady() {
+			notReady := []int{}
+			for i, player := range g.state.Players {
+				if player.IsConnected && !player.IsReady {
+					notReady = append(notReady, i)
+				}
+			}
+			return actionError(message, messages.ErrorCodeNotReady, messages.ActionErrorParams{
+				"not_ready": notReady,
+			})
+		}
+
+		g.connMu.Lock()
 		err := g.state.StartGame()
+		g.connMu.Unlock()
 		if err != nil {
-			return messages.NewActionErrorMessage(message.SenderID, messages.CouldNotStart)
+			return actionError(message, messages.ErrorCodeInvalidPlayerCount, messages.ActionErrorParams{
+				"min":   5,
+				"max":   10,
+				"count": len(g.state.Players),
+			})
 		}
 
 		g.broadcastGameState()
 
 		return messages.NewGameStateMessage(
 			"server",
-			g.state.ObfuscateGameState(*p),
+			views.ForPlayer(&g.state, p.ID),
 		)
 
 	case models.ActionInvestigate:
@@ -295,20 +490,24 @@ func (g *Game) ProcessActionMessage(message messages.ActionMessage) messages.Mes
 			return errorMessage
 		}
 
-		if g.state.Phase != models.Executive {
-			return messages.NewActionErrorMessage(message.SenderID, messages.NotAllowed)
+		if errorMessage := g.requirePhase(message, models.Executive); errorMessage != nil {
+			return errorMessage
 		}
 
 		forPlayer := g.state.GetPlayer(g.state.PresidentIndex)
 		player := g.state.GetPlayer(message.TargetIndex)
 
 		if forPlayer == nil || player == nil {
-			return messages.NewActionErrorMessage(message.SenderID, messages.InvalidAction(message.Action))
+			return actionError(message, messages.ErrorCodeInvalidTarget, messages.ActionErrorParams{
+				"target_index": message.TargetIndex,
+			})
 		}
 
+		g.state.RecordExecutiveAction(models.ActionInvestigate, message.TargetIndex)
+
 		return messages.NewGameStateMessage(
 			"server",
-			g.state.RevealPlayer(*forPlayer, *player),
+			views.WithInvestigation(&g.state, forPlayer.ID, message.TargetIndex),
 		)
 
 	case models.ActionSpecialElection:
@@ -316,10 +515,11 @@ func (g *Game) ProcessActionMessage(message messages.ActionMessage) messages.Mes
 			return errorMessage
 		}
 
-		if g.state.Phase != models.Executive {
-			return messages.NewActionErrorMessage(message.SenderID, messages.NotAllowed)
+		if errorMessage := g.requirePhase(message, models.Executive); errorMessage != nil {
+			return errorMessage
 		}
 
+		g.state.RecordExecutiveAction(models.ActionSpecialElection, message.TargetIndex)
 		g.state.ResumeOrderIndex = (g.state.PresidentIndex + 1) % len(g.state.Players)
 
 		newPresidentIndex := message.TargetIndex
@@ -336,7 +536,7 @@ func (g *Game) ProcessActionMessage(message messages.ActionMessage) messages.Mes
 
 		return messages.NewGameStateMessage(
 			"server",
-			g.state.ObfuscateGameState(*p),
+			views.ForPlayer(&g.state, p.ID),
 		)
 
 	case models.ActionPolicyPeek:
@@ -344,15 +544,16 @@ func (g *Game) ProcessActionMessage(message messages.ActionMessage) messages.Mes
 			return errorMessage
 		}
 
-		if g.state.Phase != models.Executive {
-			return messages.NewActionErrorMessage(message.SenderID, messages.NotAllowed)
+		if errorMessage := g.requirePhase(message, models.Executive); errorMessage != nil {
+			return errorMessage
 		}
 		g.state.PeekedCards = []models.Card{g.state.Deck[0]}
 		g.state.PeekerIndex = g.state.PresidentIndex
+		g.state.RecordExecutiveAction(models.ActionPolicyPeek, -1)
 
 		return messages.NewGameStateMessage(
 			"server",
-			g.state.ObfuscateGameState(*p),
+			views.ForPlayer(&g.state, p.ID),
 		)
 
 	case models.ActionExecution:
@@ -360,14 +561,15 @@ func (g *Game) ProcessActionMessage(message messages.ActionMessage) messages.Mes
 			return errorMessage
 		}
 
-		if g.state.Phase != models.Executive {
-			return messages.NewActionErrorMessage(message.SenderID, messages.NotAllowed)
+		if errorMessage := g.requirePhase(message, models.Executive); errorMessage != nil {
+			return errorMessage
 		}
 
 		targetPlayer := g.state.GetPlayer(message.TargetIndex)
 		if targetPlayer != nil {
 			targetPlayer.IsExecuted = true
 		}
+		g.state.RecordExecutiveAction(models.ActionExecution, message.TargetIndex)
 
 		if g.state.EndGameIfNecessary() {
 			g.broadcastGameState()
@@ -377,7 +579,7 @@ func (g *Game) ProcessActionMessage(message messages.ActionMessage) messages.Mes
 
 		return messages.NewGameStateMessage(
 			"server",
-			g.state.ObfuscateGameState(*p),
+			views.ForPlayer(&g.state, p.ID),
 		)
 
 	case models.ActionVote:
@@ -385,12 +587,12 @@ func (g *Game) ProcessActionMessage(message messages.ActionMessage) messages.Mes
 			return errorMessage
 		}
 
-		if g.state.Phase != models.Election {
-			return messages.NewActionErrorMessage(message.SenderID, messages.NotAllowed)
+		if errorMessage := g.requirePhase(message, models.Election); errorMessage != nil {
+			return errorMessage
 		}
 
 		if message.Vote == nil {
-			return messages.NewActionErrorMessage(message.SenderID, messages.InvalidAction(message.Action))
+			return actionError(message, messages.ErrorCodeMissingVote, nil)
 		}
 
 		if *message.Vote {
@@ -399,73 +601,13 @@ func (g *Game) ProcessActionMessage(message messages.ActionMessage) messages.Mes
 			g.state.Votes[g.state.PlayerIndexMap[message.SenderID]] = models.VoteNein
 		}
 
-		eligibleVoters := 0
-		votes := 0
-		yesVotes := 0
-		for i, vote := range g.state.Votes {
-			if g.state.Players[i].IsExecuted {
-				continue
-			}
-			eligibleVoters++
-			if vote != models.VotePending {
-				votes++
-			}
-			if vote == models.VoteJa {
-				yesVotes++
-			}
-		}
-
-		if votes == eligibleVoters {
-			if yesVotes > eligibleVoters/2 {
-				g.state.Board.ElectionTracker.FailedElections = 0
-				g.state.ChancellorIndex = g.state.NomineeIndex
-				g.state.Phase = models.Legislation1
-				g.state.PeekerIndex = g.state.PresidentIndex
-
-				// Draw 3 cards
-				if len(g.state.Deck) < 3 {
-					g.state.ShuffleDeck()
-				}
-				g.state.PeekedCards = g.state.Deck[:3]
-				g.state.Deck = g.state.Deck[3:]
-				if g.state.Players[g.state.ChancellorIndex].Role == models.RoleHitler && g.state.Board.FascistPolicies >= g.state.Board.DangerZoneStart {
-					g.state.EndGame(models.TeamFascist)
-					g.broadcastGameState()
-					return messages.NewGameStateMessage(
-						"server",
-						g.state.ObfuscateGameState(*p),
-					)
-				}
-			} else {
-				g.state.Board.ElectionTracker.FailedElections++
-
-				if g.state.Board.ElectionTracker.FailedElections == g.state.Board.ElectionTracker.MaxFailures {
-					g.state.Board.ElectionTracker.FailedElections = 0
-					// Draw top policy
-					if len(g.state.Deck) < 1 {
-						g.state.ShuffleDeck()
-					}
-					topCard := g.state.Deck[0]
-					g.state.Deck = g.state.Deck[1:]
-
-					if g.PlaceCard(topCard) {
-						g.broadcastGameState()
-						return messages.NewGameStateMessage(
-							"server",
-							g.state.ObfuscateGameState(*p),
-						)
-					}
-				} else {
-					g.NewTurn()
-				}
-			}
-		}
+		g.tallyVotes()
 
 		g.broadcastGameState()
 
 		return messages.NewGameStateMessage(
 			"server",
-			g.state.ObfuscateGameState(*p),
+			views.ForPlayer(&g.state, p.ID),
 		)
 
 	case models.ActionNominate:
@@ -473,15 +615,22 @@ func (g *Game) ProcessActionMessage(message messages.ActionMessage) messages.Mes
 			return errorMessage
 		}
 
-		if g.state.Phase != models.Nomination {
-			return messages.NewActionErrorMessage(message.SenderID, messages.NotAllowed)
+		if errorMessage := g.requirePhase(message, models.Nomination); errorMessage != nil {
+			return errorMessage
+		}
+
+		if message.TargetIndex == g.state.PresidentIndex {
+			return actionError(message, messages.ErrorCodeInvalidTarget, messages.ActionErrorParams{
+				"target_index": message.TargetIndex,
+			})
 		}
 
-		if message.TargetIndex == g.state.PresidentIndex ||
-			message.TargetIndex == g.state.PrevPresidentIndex ||
+		if message.TargetIndex == g.state.PrevPresidentIndex ||
 			message.TargetIndex == g.state.PrevChancellorIndex {
 
-			return messages.NewActionErrorMessage(message.SenderID, messages.InvalidTarget)
+			return actionError(message, messages.ErrorCodeTermLimited, messages.ActionErrorParams{
+				"target_index": message.TargetIndex,
+			})
 		}
 
 		g.state.NomineeIndex = message.TargetIndex
@@ -492,7 +641,7 @@ func (g *Game) ProcessActionMessage(message messages.ActionMessage) messages.Mes
 
 		return messages.NewGameStateMessage(
 			"server",
-			g.state.ObfuscateGameState(*p),
+			views.ForPlayer(&g.state, p.ID),
 		)
 
 	case models.ActionLegislate:
@@ -500,13 +649,19 @@ func (g *Game) ProcessActionMessage(message messages.ActionMessage) messages.Mes
 			return errorMessage
 		}
 
+		if errorMessage := g.requirePhase(message, models.Legislation1, models.Legislation2); errorMessage != nil {
+			return errorMessage
+		}
+
 		peeker := g.state.GetPlayer(g.state.PeekerIndex)
 		if peeker == nil || message.SenderID != peeker.ID {
-			return messages.NewActionErrorMessage(message.SenderID, messages.NotAllowed)
+			return actionError(message, messages.ErrorCodeNotYourTurn, nil)
 		}
 
 		if message.TargetIndex < 0 || message.TargetIndex >= len(g.state.PeekedCards) {
-			return messages.NewActionErrorMessage(message.SenderID, messages.InvalidAction(message.Action))
+			return actionError(message, messages.ErrorCodeInvalidCard, messages.ActionErrorParams{
+				"card_index": message.TargetIndex,
+			})
 		}
 
 		switch g.state.Phase {
@@ -522,6 +677,7 @@ func (g *Game) ProcessActionMessage(message messages.ActionMessage) messages.Mes
 			g.state.PeekedCards = nil
 			g.state.PeekerIndex = -1
 
+			g.state.RecordPolicy(remainingCard, false)
 			g.PlaceCard(remainingCard)
 		}
 
@@ -529,7 +685,131 @@ func (g *Game) ProcessActionMessage(message messages.ActionMessage) messages.Mes
 
 		return messages.NewGameStateMessage(
 			"server",
-			g.state.ObfuscateGameState(*p),
+			views.ForPlayer(&g.state, p.ID),
+		)
+
+	case models.ActionVoteContinue:
+		if errorMessage := g.validateActionMessage(message, false, false); errorMessage != nil {
+			return errorMessage
+		}
+
+		if errorMessage := g.voteContinue(message); errorMessage != nil {
+			return errorMessage
+		}
+
+		g.broadcastGameState()
+
+		return messages.NewGameStateMessage(
+			"server",
+			views.ForPlayer(&g.state, p.ID),
+		)
+
+	case models.ActionAbortGame:
+		if errorMessage := g.abortGame(message); errorMessage != nil {
+			return errorMessage
+		}
+
+		g.broadcastGameState()
+
+		return messages.NewGameStateMessage(
+			"server",
+			views.ForPlayer(&g.state, p.ID),
+		)
+
+	case models.ActionRematch:
+		if errorMessage := g.rematch(message); errorMessage != nil {
+			return errorMessage
+		}
+
+		g.broadcastGameState()
+
+		return messages.NewGameStateMessage(
+			"server",
+			views.ForPlayer(&g.state, p.ID),
+		)
+
+	case models.ActionReadyCheck:
+		if errorMessage := g.startReadyCheck(message); errorMessage != nil {
+			return errorMessage
+		}
+
+		g.broadcastGameState()
+
+		return messages.NewGameStateMessage(
+			"server",
+			views.ForPlayer(&g.state, p.ID),
+		)
+
+	case models.ActionReady:
+		if errorMessage := g.setReady(message); errorMessage != nil {
+			return errorMessage
+		}
+
+		g.broadcastGameState()
+
+		return messages.NewGameStateMessage(
+			"server",
+			views.ForPlayer(&g.state, p.ID),
+		)
+
+	case models.ActionKickPlayer:
+		if errorMessage := g.kickPlayer(message); errorMessage != nil {
+			return errorMessage
+		}
+
+		g.broadcastGameState()
+
+		return messages.NewGameStateMessage(
+			"server",
+			views.ForPlayer(&g.state, message.SenderID),
+		)
+
+	case models.ActionTransferHost:
+		if errorMessage := g.transferHost(message); errorMessage != nil {
+			return errorMessage
+		}
+
+		g.broadcastGameState()
+
+		return messages.NewGameStateMessage(
+			"server",
+			views.ForPlayer(&g.state, message.SenderID),
+		)
+
+	case models.ActionLockLobby:
+		if errorMessage := g.setLobbyLocked(message, true); errorMessage != nil {
+			return errorMessage
+		}
+
+		g.broadcastGameState()
+
+		return messages.NewGameStateMessage(
+			"server",
+			views.ForPlayer(&g.state, message.SenderID),
+		)
+
+	case models.ActionUnlockLobby:
+		if errorMessage := g.setLobbyLocked(message, false); errorMessage != nil {
+			return errorMessage
+		}
+
+		g.broadcastGameState()
+
+		return messages.NewGameStateMessage(
+			"server",
+			views.ForPlayer(&g.state, message.SenderID),
+		)
+
+	case models.ActionReorderSeats:
+		if errorMessage := g.reorderSeats(message); errorMessage != nil {
+			return errorMessage
+		}
+
+		g.broadcastGameState()
+
+		return messages.NewGameStateMessage(
+			"server",
+			views.ForPlayer(&g.state, message.SenderID),
 		)
 
 	case models.ActionProposeVeto:
@@ -543,17 +823,17 @@ func (g *Game) ProcessActionMessage(message messages.ActionMessage) messages.Mes
 			return errorMessage
 		}
 
-		if g.state.Phase != models.Executive {
-			return messages.NewActionErrorMessage(message.SenderID, messages.NotAllowed)
+		if errorMessage := g.requirePhase(message, models.Executive); errorMessage != nil {
+			return errorMessage
 		}
 
 		g.NewTurn()
 
 		return messages.NewGameStateMessage(
 			"server",
-			g.state.ObfuscateGameState(*p),
+			views.ForPlayer(&g.state, p.ID),
 		)
 	}
 
-	return messages.NewActionErrorMessage(message.SenderID, messages.InvalidAction(message.Action))
+	return actionError(message, messages.ErrorCodeUnknownAction, nil)
 }
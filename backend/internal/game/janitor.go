@@ -0,0 +1,135 @@
+package game
+
+import (
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/envs"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/models"
+)
+
+// janitor periodically removes games that nobody is coming back to
+type janitor struct {
+	config envs.JanitorConfig
+	stop   chan struct{}
+	done   chan struct{}
+	mu     sync.Mutex
+	reaped map[models.GamePhase]int
+}
+
+// ReaperStats counts the games removed by the janitor, by the phase they were in
+type ReaperStats struct {
+	Reaped map[models.GamePhase]int `json:"reaped"`
+}
+
+// StartJanitor starts reaping idle games in the background. It is stopped by
+// BeginShutdown so games aren't removed while they are being saved.
+func (m *Manager) StartJanitor(config envs.JanitorConfig) {
+	j := &janitor{
+		config: config,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+		reaped: make(map[models.GamePhase]int),
+	}
+
+	m.mu.Lock()
+	m.janitor = j
+	m.mu.Unlock()
+
+	go func() {
+		defer close(j.done)
+		ticker := time.NewTicker(config.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-j.stop:
+				return
+			case now := <-ticker.C:
+				m.reapIdleGames(j, now)
+			}
+		}
+	}()
+}
+
+func (m *Manager) stopJanitor() {
+	m.mu.Lock()
+	j := m.janitor
+	m.janitor = nil
+	m.mu.Unlock()
+
+	if j != nil {
+		close(j.stop)
+		<-j.done
+	}
+}
+
+func (m *Manager) ReaperStats() ReaperStats {
+	m.mu.RLock()
+	j := m.janitor
+	m.mu.RUnlock()
+
+	if j == nil {
+		return ReaperStats{Reaped: map[models.GamePhase]int{}}
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return ReaperStats{Reaped: maps.Clone(j.reaped)}
+}
+
+func (m *Manager) reapIdleGames(j *janitor, now time.Time) {
+	for _, game := range m.allGames() {
+		phase, idle, reapable := game.idleStatus(j.config, now)
+		if !reapable {
+			continue
+		}
+
+		m.RemoveGame(game.ID)
+		game.Close()
+
+		j.mu.Lock()
+		j.reaped[phase]++
+		j.mu.Unlock()
+
+		fmt.Println("Reaped game", game.ID, "in phase", phase, "idle for", idle.Round(time.Second), "sent:", game.Metrics().Snapshot())
+	}
+}
+
+// idleStatus reports whether the game has been idle longer than its phase
+// allows. A paused game is reaped even with players still connected, since it
+// can't go on without the ones who left.
+func (g *Game) idleStatus(config envs.JanitorConfig, now time.Time) (models.GamePhase, time.Duration, bool) {
+	g.activityMu.Lock()
+	phase := g.activityPhase
+	idle := now.Sub(g.lastActivity)
+	g.activityMu.Unlock()
+
+	g.connMu.RLock()
+	connected := len(g.Connections)
+	g.connMu.RUnlock()
+
+	switch phase {
+	case models.Setup, models.ReadyCheck:
+		return phase, idle, connected == 0 && idle > config.SetupTTL
+	case models.GameOver:
+		return phase, idle, connected == 0 && idle > config.GameOverTTL
+	case models.Paused:
+		return phase, idle, idle > config.PausedTTL
+	}
+	return phase, idle, false
+}
+
+// touch records activity on the game along with the phase it left the game in,
+// and keeps its lobby listing current. Callers must be the Run goroutine or
+// hold connMu, so the state reads are safe.
+func (g *Game) touch() {
+	g.activityMu.Lock()
+	g.lastActivity = time.Now()
+	g.activityPhase = g.state.Phase
+	g.activityMu.Unlock()
+
+	g.refreshListing()
+}
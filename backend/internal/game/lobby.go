@@ -0,0 +1,206 @@
+package game
+
+import (
+	"slices"
+	"time"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/messages"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/models"
+)
+
+// requireHostInLobby refuses lobby controls from anyone but the host, or once
+// the game has started
+func (g *Game) requireHostInLobby(message messages.ActionMessage) *messages.ActionErrorMessage {
+	if message.SenderID != g.HostID {
+		return actionError(message, messages.ErrorCodeNotHost, nil)
+	}
+	return g.requirePhase(message, models.Setup, models.ReadyCheck)
+}
+
+// requireOtherPlayer refuses targets that aren't a seat or are the host themselves
+func (g *Game) requireOtherPlayer(message messages.ActionMessage) (*models.Player, *messages.ActionErrorMessage) {
+	target := g.state.GetPlayer(message.TargetIndex)
+	if target == nil || target.ID == message.SenderID {
+		return nil, actionError(message, messages.ErrorCodeInvalidTarget, messages.ActionErrorParams{
+			"target_index": message.TargetIndex,
+		})
+	}
+	return target, nil
+}
+
+// kickPlayer removes a player from the lobby. Their ID stops working, so they
+// can only come back by joining again.
+func (g *Game) kickPlayer(message messages.ActionMessage) *messages.ActionErrorMessage {
+	if errorMessage := g.requireHostInLobby(message); errorMessage != nil {
+		return errorMessage
+	}
+	target, errorMessage := g.requireOtherPlayer(message)
+	if errorMessage != nil {
+		return errorMessage
+	}
+
+	if err := g.removeFromLobby(target.ID, "You were removed from the lobby by the host"); err != nil {
+		return actionError(message, messages.ErrorCodeWrongPhase, messages.ActionErrorParams{
+			"actual": g.state.Phase,
+		})
+	}
+	return nil
+}
+
+// removeFromLobby takes a player out of the lobby and tells them why, passing
+// the host on if it was them
+func (g *Game) removeFromLobby(id string, reason string) error {
+	g.connMu.Lock()
+	conn, err := g.dropFromLobby(id)
+	g.connMu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	g.forgetStreams(id)
+	if conn != nil {
+		g.sendMessage(conn, messages.NewConnectionErrorMessage("server", reason, messages.ConnectionErrorTypeKicked))
+		conn.Close()
+	}
+	return nil
+}
+
+// dropFromLobby removes the player and their connection, returning the
+// connection so they can be told. Callers hold connMu.
+func (g *Game) dropFromLobby(id string) (Client, error) {
+	if err := g.state.RemovePlayer(id); err != nil {
+		return nil, err
+	}
+	conn := g.Connections[id]
+	delete(g.Connections, id)
+	delete(g.pendingLeaves, id)
+	if g.HostID == id && len(g.state.Players) > 0 {
+		g.SetHostID(g.state.Players[0].ID)
+	}
+	return conn, nil
+}
+
+func (g *Game) forgetStreams(id string) {
+	g.sendMu.Lock()
+	delete(g.streams, id)
+	delete(g.requests, id)
+	g.sendMu.Unlock()
+}
+
+type pendingLeave struct {
+	id  string
+	seq int
+}
+
+// scheduleRemovePlayer gives a player who left the lobby a grace period to
+// come back with the same ID before their seat is freed. Callers hold connMu.
+func (g *Game) scheduleRemovePlayer(id string) {
+	g.scheduleRemovePlayerAfter(id, g.manager.config.LobbyGrace)
+}
+
+// scheduleRemovePlayerAfter frees the seat of a player who isn't connected
+// once delay is up, unless they connect first. Callers hold connMu.
+func (g *Game) scheduleRemovePlayerAfter(id string, delay time.Duration) {
+	g.leaveSeq++
+	leave := pendingLeave{id: id, seq: g.leaveSeq}
+	g.pendingLeaves[id] = leave.seq
+
+	time.AfterFunc(delay, func() {
+		select {
+		case g.leaveExpired <- leave:
+		case <-g.done:
+		}
+	})
+}
+
+// cancelRemovePlayer keeps the seat of a player who came back. Callers hold connMu.
+func (g *Game) cancelRemovePlayer(id string) {
+	delete(g.pendingLeaves, id)
+}
+
+// removeAfterGrace frees the seat of a player who didn't come back in time.
+// Only called from Run.
+func (g *Game) removeAfterGrace(leave pendingLeave) {
+	g.connMu.Lock()
+	// Coming back, or leaving again since, makes this timer stale
+	if g.pendingLeaves[leave.id] != leave.seq {
+		g.connMu.Unlock()
+		return
+	}
+	delete(g.pendingLeaves, leave.id)
+
+	player := g.state.GetPlayerByID(leave.id)
+	if player == nil || player.IsConnected || !g.state.InLobby() {
+		g.connMu.Unlock()
+		return
+	}
+	username := player.Username
+	if _, err := g.dropFromLobby(leave.id); err != nil {
+		g.connMu.Unlock()
+		return
+	}
+	g.connMu.Unlock()
+
+	g.forgetStreams(leave.id)
+	g.broadcastGameState()
+	g.persist(EventPlayerLeft, map[string]string{"username": username}, g.takeSnapshot())
+}
+
+func (g *Game) transferHost(message messages.ActionMessage) *messages.ActionErrorMessage {
+	if errorMessage := g.requireHostInLobby(message); errorMessage != nil {
+		return errorMessage
+	}
+	target, errorMessage := g.requireOtherPlayer(message)
+	if errorMessage != nil {
+		return errorMessage
+	}
+
+	g.connMu.Lock()
+	g.SetHostID(target.ID)
+	g.connMu.Unlock()
+	return nil
+}
+
+// setLobbyLocked stops or allows new players joining the lobby
+func (g *Game) setLobbyLocked(message messages.ActionMessage, locked bool) *messages.ActionErrorMessage {
+	if errorMessage := g.requireHostInLobby(message); errorMessage != nil {
+		return errorMessage
+	}
+
+	g.connMu.Lock()
+	g.state.LobbyLocked = locked
+	g.connMu.Unlock()
+	return nil
+}
+
+// reorderSeats changes the order players sit in, which is the order the
+// presidency goes around the table
+func (g *Game) reorderSeats(message messages.ActionMessage) *messages.ActionErrorMessage {
+	if errorMessage := g.requireHostInLobby(message); errorMessage != nil {
+		return errorMessage
+	}
+
+	g.connMu.Lock()
+	defer g.connMu.Unlock()
+
+	// The host may have picked the order before someone joined or left, so it
+	// is checked against the lobby as it is now
+	order := message.SeatOrder
+	sorted := slices.Sorted(slices.Values(order))
+	valid := len(order) == len(g.state.Players)
+	for i, seat := range sorted {
+		valid = valid && seat == i
+	}
+	if !valid {
+		return actionError(message, messages.ErrorCodeInvalidSeatOrder, messages.ActionErrorParams{
+			"seats": len(g.state.Players),
+		})
+	}
+
+	if err := g.state.ReorderSeats(order); err != nil {
+		return actionError(message, messages.ErrorCodeWrongPhase, messages.ActionErrorParams{
+			"actual": g.state.Phase,
+		})
+	}
+	return nil
+}
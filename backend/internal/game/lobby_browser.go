@@ -0,0 +1,137 @@
+package game
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/models"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/repository"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/views"
+)
+
+// Visibility decides whether a game shows up in the public lobby browser
+type Visibility string
+
+const (
+	VisibilityPrivate Visibility = "private"
+	VisibilityPublic  Visibility = "public"
+)
+
+// There is only one rule set so far, listings carry it so clients don't have to guess
+const VariantStandard = "standard"
+
+func ParseVisibility(s string) (Visibility, error) {
+	switch Visibility(s) {
+	case "", VisibilityPrivate:
+		return VisibilityPrivate, nil
+	case VisibilityPublic:
+		return VisibilityPublic, nil
+	}
+	return "", repository.ErrInvalidVisibility
+}
+
+func (g *Game) SetVisibility(visibility Visibility) {
+	g.activityMu.Lock()
+	g.visibility = visibility
+	g.activityMu.Unlock()
+
+	g.connMu.RLock()
+	defer g.connMu.RUnlock()
+	g.refreshListing()
+}
+
+func (g *Game) Visibility() Visibility {
+	g.activityMu.Lock()
+	defer g.activityMu.Unlock()
+	return g.visibility
+}
+
+// refreshListing puts the game in the manager's lobby index or takes it out.
+// Only public games still in setup that someone could actually join are
+// listed. Like touch, it reads the state so it has to run where touch does.
+func (g *Game) refreshListing() {
+	if g.manager == nil {
+		return
+	}
+
+	g.activityMu.Lock()
+	visibility, createdAt := g.visibility, g.createdAt
+	g.activityMu.Unlock()
+
+	state := &g.state
+	joinable := visibility == VisibilityPublic &&
+		state.Phase == models.Setup &&
+		!state.LobbyLocked &&
+		len(state.Players) > 0 &&
+		len(state.Players) < models.MaxPlayers &&
+		!g.IsInviteOnly()
+	if !joinable {
+		g.manager.unlist(g.ID)
+		return
+	}
+
+	listing := views.LobbyListing{
+		GameID:            g.ID,
+		PlayerCount:       len(state.Players),
+		MaxPlayers:        models.MaxPlayers,
+		Variant:           VariantStandard,
+		PasswordProtected: g.IsPasswordProtected(),
+		Streamed:          g.StreamerOptions().Enabled,
+		CreatedAtUnix:     createdAt.Unix(),
+	}
+	if host := state.GetPlayerByID(g.HostID); host != nil {
+		listing.HostName = host.Username
+	}
+	g.manager.list(listing)
+}
+
+// lobbyIndex holds the listings of public games waiting for players. seq goes
+// up on every change so feeds only resend when there is something new.
+type lobbyIndex struct {
+	listings map[string]views.LobbyListing
+	seq      int
+}
+
+func (m *Manager) list(listing views.LobbyListing) {
+	m.lobbyMu.Lock()
+	defer m.lobbyMu.Unlock()
+
+	if current, exists := m.lobby.listings[listing.GameID]; exists && current == listing {
+		return
+	}
+	m.lobby.listings[listing.GameID] = listing
+	m.lobby.seq++
+}
+
+func (m *Manager) unlist(id string) {
+	m.lobbyMu.Lock()
+	defer m.lobbyMu.Unlock()
+
+	if _, exists := m.lobby.listings[id]; !exists {
+		return
+	}
+	delete(m.lobby.listings, id)
+	m.lobby.seq++
+}
+
+// PublicLobbies lists the public games waiting for players, newest first,
+// along with a sequence number that changes whenever the list does
+func (m *Manager) PublicLobbies() ([]views.LobbyListing, int) {
+	m.lobbyMu.Lock()
+	listings := make([]views.LobbyListing, 0, len(m.lobby.listings))
+	for _, listing := range m.lobby.listings {
+		listings = append(listings, listing)
+	}
+	seq := m.lobby.seq
+	m.lobbyMu.Unlock()
+
+	// A game is indexed a moment before it is added to the manager
+	listings = slices.DeleteFunc(listings, func(listing views.LobbyListing) bool {
+		_, exists := m.GetGame(listing.GameID)
+		return !exists
+	})
+	slices.SortFunc(listings, func(a, b views.LobbyListing) int {
+		return cmp.Or(cmp.Compare(b.CreatedAtUnix, a.CreatedAtUnix), cmp.Compare(a.GameID, b.GameID))
+	})
+	return listings, seq
+}
@@ -0,0 +1,161 @@
+package game
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/messages"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/models"
+)
+
+// Run with -race: joins come in from HTTP handlers while the host works the lobby
+func TestJoinsDuringLobbyChanges(t *testing.T) {
+	g := newTestGame(t)
+	g.SetHostID("id0")
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := range 20 {
+			// A full lobby refuses some of these, which is fine
+			g.NewPlayer(fmt.Sprintf("joiner%d", i))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := range 20 {
+			g.Submit(messages.ActionMessage{
+				BaseMessage: messages.BaseMessage{Type: messages.MessageTypeAction, SenderID: "id0"},
+				Action:      models.ActionKickPlayer,
+				TargetIndex: 1,
+			})
+			g.Submit(messages.ActionMessage{
+				BaseMessage: messages.BaseMessage{Type: messages.MessageTypeAction, SenderID: "id0"},
+				Action:      models.ActionReorderSeats,
+				SeatOrder:   []int{0, 2, 1, 3, 4}[:min(5, 2+i%4)],
+			})
+		}
+	}()
+	wg.Wait()
+
+	snapshot := g.Snapshot()
+	for id, index := range snapshot.State.PlayerIndexMap {
+		if snapshot.State.Players[index].ID != id {
+			t.Errorf("index of %s points at %s", id, snapshot.State.Players[index].ID)
+		}
+	}
+	if len(snapshot.State.PlayerIndexMap) != len(snapshot.State.Players) {
+		t.Errorf("%d players but %d indexed", len(snapshot.State.Players), len(snapshot.State.PlayerIndexMap))
+	}
+}
+
+func TestNewPlayerOnClosedGame(t *testing.T) {
+	g := newTestGame(t)
+	g.Close()
+
+	if _, err := g.NewPlayer("late"); err == nil {
+		t.Error("a closed game seated a player")
+	}
+}
+
+// Run with -race: a grace period running out frees a seat while others join
+func TestJoinsDuringGraceExpiry(t *testing.T) {
+	g := newTestGame(t)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := range 10 {
+			g.NewPlayer(fmt.Sprintf("joiner%d", i))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		// Nobody in the test game is connected, so every seat can be freed
+		for i := range 5 {
+			g.connMu.Lock()
+			g.scheduleRemovePlayerAfter(fmt.Sprintf("id%d", i), 0)
+			g.connMu.Unlock()
+		}
+	}()
+	wg.Wait()
+
+	// Wait for the removals to go through Run, in whatever order they fire
+	seated := func() bool {
+		for i := range 5 {
+			if g.HasPlayer(fmt.Sprintf("id%d", i)) {
+				return true
+			}
+		}
+		return false
+	}
+	deadline := time.Now().Add(time.Second)
+	for seated() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	snapshot := g.Snapshot()
+	for i := range 5 {
+		if _, exists := snapshot.State.PlayerIndexMap[fmt.Sprintf("id%d", i)]; exists {
+			t.Errorf("id%d kept their seat after the grace period", i)
+		}
+	}
+	for id, index := range snapshot.State.PlayerIndexMap {
+		if snapshot.State.Players[index].ID != id {
+			t.Errorf("index of %s points at %s", id, snapshot.State.Players[index].ID)
+		}
+	}
+}
+
+func TestReconnectBeforeOldConnectionCloses(t *testing.T) {
+	g := newTestGame(t)
+	old := &recordingClient{}
+	fresh := &recordingClient{}
+
+	if err := g.AddConnection("id1", old, ConnectionOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	// The refresh connects before the old socket's read loop ends
+	if err := g.AddConnection("id1", fresh, ConnectionOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if !old.isClosed() {
+		t.Error("the replaced connection was left open")
+	}
+	if err := g.DropConnection("id1", old); err != nil {
+		t.Fatal(err)
+	}
+
+	g.connMu.RLock()
+	conn := g.Connections["id1"]
+	connected := g.state.GetPlayerByID("id1").IsConnected
+	_, leaving := g.pendingLeaves["id1"]
+	g.connMu.RUnlock()
+
+	if conn != fresh {
+		t.Error("the late close dropped the new connection")
+	}
+	if !connected {
+		t.Error("the player was marked disconnected by their old connection")
+	}
+	if leaving {
+		t.Error("the player's seat is set to be freed")
+	}
+
+	// Closing the live connection still counts
+	if err := g.DropConnection("id1", fresh); err != nil {
+		t.Fatal(err)
+	}
+	if !g.HasPlayer("id1") {
+		t.Fatal("the player lost their seat straight away")
+	}
+	g.connMu.RLock()
+	_, leaving = g.pendingLeaves["id1"]
+	g.connMu.RUnlock()
+	if !leaving {
+		t.Error("closing the live connection didn't start the grace period")
+	}
+}
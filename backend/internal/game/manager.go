@@ -1,17 +1,32 @@
 package game
 
 import (
+	"fmt"
 	"sync"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/envs"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/repository"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/views"
 )
 
 type Manager struct {
-	Games map[string]*Game
-	mu    sync.RWMutex
+	Games        map[string]*Game
+	mu           sync.RWMutex
+	shuttingDown bool
+	store        repository.GameStore
+	janitor      *janitor
+	matchmaker   *matchmaker
+	config       envs.GameConfig
+	lobby        lobbyIndex
+	lobbyMu      sync.Mutex
 }
 
-func NewManager() *Manager {
+func NewManager(store repository.GameStore, config envs.GameConfig) *Manager {
 	return &Manager{
-		Games: make(map[string]*Game),
+		Games:  make(map[string]*Game),
+		store:  store,
+		config: config,
+		lobby:  lobbyIndex{listings: make(map[string]views.LobbyListing)},
 	}
 }
 
@@ -29,8 +44,24 @@ func (m *Manager) AddGame(game *Game) string {
 	return game.ID
 }
 
+func (m *Manager) GameCount() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.Games)
+}
+
 func (m *Manager) RemoveGame(id string) {
 	m.mu.Lock()
-	defer m.mu.Unlock()
+	game, exists := m.Games[id]
 	delete(m.Games, id)
+	m.unlist(id)
+	m.mu.Unlock()
+
+	// A write still on its way would bring the game back after the delete
+	if exists {
+		game.persister.stop()
+	}
+	if err := m.store.DeleteSnapshot(id); err != nil {
+		fmt.Println("Failed to delete stored game", id, err)
+	}
 }
@@ -0,0 +1,325 @@
+package game
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/envs"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/models"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/repository"
+)
+
+// Match is the seat a queued player was given
+type Match struct {
+	GameID    string `json:"game_id"`
+	PlayerID  string `json:"player_id"`
+	TableSize int    `json:"table_size"`
+}
+
+// ticket is a player's place in the queue. It stays around after a match until
+// the player picks the match up, or it expires.
+type ticket struct {
+	id            string
+	username      string
+	preferredSize int
+	enqueuedAt    time.Time
+	match         *Match
+	err           error
+	claimed       bool // Taken out of the queue for a table that is being created
+	resolved      bool
+	ready         chan struct{} // Closed once the ticket is matched or dropped
+}
+
+// resolve settles the ticket once, the first outcome wins. Callers hold the
+// matchmaker's lock.
+func (t *ticket) resolve(match *Match, err error) {
+	if t.resolved {
+		return
+	}
+	t.resolved = true
+	t.match = match
+	t.err = err
+	close(t.ready)
+}
+
+// matchmaker groups queued players into tables and creates their games
+type matchmaker struct {
+	config  envs.MatchmakingConfig
+	stop    chan struct{}
+	done    chan struct{}
+	mu      sync.Mutex
+	waiting []*ticket // Oldest first
+	tickets map[string]*ticket
+}
+
+// StartMatchmaking starts the quick play queue. Like the janitor it is stopped
+// by BeginShutdown.
+func (m *Manager) StartMatchmaking(config envs.MatchmakingConfig) {
+	mm := &matchmaker{
+		config:  config,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+		tickets: make(map[string]*ticket),
+	}
+
+	m.mu.Lock()
+	m.matchmaker = mm
+	m.mu.Unlock()
+
+	go func() {
+		defer close(mm.done)
+		ticker := time.NewTicker(config.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-mm.stop:
+				return
+			case now := <-ticker.C:
+				mm.expireTickets(now)
+				m.formTables(mm, now)
+			}
+		}
+	}()
+}
+
+// stopMatchmaking stops the queue and lets everyone still waiting know
+func (m *Manager) stopMatchmaking() {
+	m.mu.Lock()
+	mm := m.matchmaker
+	m.matchmaker = nil
+	m.mu.Unlock()
+
+	if mm == nil {
+		return
+	}
+	close(mm.stop)
+	<-mm.done
+
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+	for id, t := range mm.tickets {
+		if t.match == nil {
+			t.resolve(nil, repository.ErrMatchmakingClosed)
+		}
+		delete(mm.tickets, id)
+	}
+	mm.waiting = nil
+}
+
+func (m *Manager) getMatchmaker() *matchmaker {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.matchmaker
+}
+
+// Enqueue puts a player in the quick play queue and returns their ticket ID
+func (m *Manager) Enqueue(username string, preferredSize int) (string, error) {
+	if preferredSize < models.MinPlayers || preferredSize > models.MaxPlayers {
+		return "", repository.ErrInvalidTableSize
+	}
+
+	// Caught now rather than when their table is already being seated
+	username, err := models.NormalizeUsername(username)
+	if err != nil {
+		return "", err
+	}
+
+	mm := m.getMatchmaker()
+	if mm == nil {
+		return "", repository.ErrMatchmakingClosed
+	}
+
+	t := &ticket{
+		id:            generateRandomID(16),
+		username:      username,
+		preferredSize: preferredSize,
+		enqueuedAt:    time.Now(),
+		ready:         make(chan struct{}),
+	}
+
+	mm.mu.Lock()
+	mm.tickets[t.id] = t
+	mm.waiting = append(mm.waiting, t)
+	mm.mu.Unlock()
+
+	// A full table may be waiting on just this player
+	m.formTables(mm, time.Now())
+	return t.id, nil
+}
+
+// WaitForMatch blocks until the ticket gets a seat. If cancel fires first the
+// player is taken out of the queue.
+func (m *Manager) WaitForMatch(ticketID string, cancel <-chan struct{}) (Match, error) {
+	mm := m.getMatchmaker()
+	if mm == nil {
+		return Match{}, repository.ErrMatchmakingClosed
+	}
+
+	mm.mu.Lock()
+	t, exists := mm.tickets[ticketID]
+	mm.mu.Unlock()
+	if !exists {
+		return Match{}, repository.ErrTicketNotFound
+	}
+
+	select {
+	case <-t.ready:
+	case <-cancel:
+		mm.mu.Lock()
+		if !t.claimed {
+			mm.waiting = slices.DeleteFunc(mm.waiting, func(w *ticket) bool { return w == t })
+			delete(mm.tickets, t.id)
+			mm.mu.Unlock()
+			return Match{}, repository.ErrTicketNotFound
+		}
+		mm.mu.Unlock()
+
+		// Their table is already being seated, so once it is their seat is
+		// given back rather than left for nobody
+		<-t.ready
+		mm.mu.Lock()
+		delete(mm.tickets, t.id)
+		mm.mu.Unlock()
+		if t.match != nil {
+			m.releaseSeat(*t.match)
+		}
+		return Match{}, repository.ErrTicketNotFound
+	}
+
+	mm.mu.Lock()
+	delete(mm.tickets, t.id)
+	mm.mu.Unlock()
+
+	if t.err != nil {
+		return Match{}, t.err
+	}
+	return *t.match, nil
+}
+
+// expireTickets drops players who have waited too long, along with matches
+// nobody came to pick up
+func (mm *matchmaker) expireTickets(now time.Time) {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	for id, t := range mm.tickets {
+		if now.Sub(t.enqueuedAt) < mm.config.TicketTTL {
+			continue
+		}
+		// A claimed ticket is settled by its table, whose seat expires on its own
+		if t.match == nil && !t.claimed {
+			t.resolve(nil, repository.ErrTicketExpired)
+		}
+		delete(mm.tickets, id)
+	}
+	mm.waiting = slices.DeleteFunc(mm.waiting, func(t *ticket) bool {
+		_, exists := mm.tickets[t.id]
+		return !exists
+	})
+}
+
+// accepts reports whether the ticket would sit at a table of the given size.
+// Players take their preferred size, or once they have waited long enough any
+// size from the minimum up to it.
+func (mm *matchmaker) accepts(t *ticket, size int, now time.Time) bool {
+	if size == t.preferredSize {
+		return true
+	}
+	return now.Sub(t.enqueuedAt) >= mm.config.FallbackAfter && size >= models.MinPlayers && size <= t.preferredSize
+}
+
+// nextTable takes the longest waiting players for the biggest table that can
+// be filled, or returns nil if none can. Players sharing a name are kept at
+// different tables.
+func (mm *matchmaker) nextTable(now time.Time) []*ticket {
+	for size := models.MaxPlayers; size >= models.MinPlayers; size-- {
+		var table []*ticket
+		names := make(map[string]bool)
+		for _, t := range mm.waiting {
+			key := models.UsernameKey(t.username)
+			if mm.accepts(t, size, now) && !names[key] {
+				table = append(table, t)
+				names[key] = true
+			}
+			if len(table) == size {
+				break
+			}
+		}
+		if len(table) < size {
+			continue
+		}
+
+		mm.waiting = slices.DeleteFunc(mm.waiting, func(t *ticket) bool { return slices.Contains(table, t) })
+		for _, t := range table {
+			t.claimed = true
+		}
+		return table
+	}
+	return nil
+}
+
+func (m *Manager) formTables(mm *matchmaker, now time.Time) {
+	for {
+		mm.mu.Lock()
+		table := mm.nextTable(now)
+		mm.mu.Unlock()
+
+		if table == nil {
+			return
+		}
+		m.createTable(mm, table)
+	}
+}
+
+// createTable creates the game for a table, seating players in the order they
+// queued, so the longest waiting one hosts
+func (m *Manager) createTable(mm *matchmaker, table []*ticket) {
+	g := NewGame(m)
+	matches := make([]*Match, len(table))
+	errs := make([]error, len(table))
+	for i, t := range table {
+		player, err := g.NewPlayer(t.username)
+		if err != nil {
+			fmt.Println("Failed to seat", t.username, "in matchmade game", g.ID, err)
+			errs[i] = err
+			continue
+		}
+		matches[i] = &Match{GameID: g.ID, PlayerID: player.ID, TableSize: len(table)}
+	}
+
+	// Matched players who never connect lose their seat like anyone else who
+	// left the lobby, so the table isn't stuck waiting on them
+	g.connMu.Lock()
+	for _, match := range matches {
+		if match != nil {
+			g.scheduleRemovePlayer(match.PlayerID)
+		}
+	}
+	g.connMu.Unlock()
+	m.AddGame(g)
+
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+	for i, t := range table {
+		if matches[i] == nil {
+			t.resolve(nil, errs[i])
+			continue
+		}
+		t.resolve(matches[i], nil)
+	}
+}
+
+// releaseSeat frees the seat of a player who left the queue while their table
+// was being created
+func (m *Manager) releaseSeat(match Match) {
+	g, exists := m.GetGame(match.GameID)
+	if !exists {
+		return
+	}
+	g.connMu.Lock()
+	g.scheduleRemovePlayerAfter(match.PlayerID, 0)
+	g.connMu.Unlock()
+}
@@ -0,0 +1,54 @@
+package game
+
+import (
+	"sync"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/messages"
+)
+
+type MessageStats struct {
+	Count int64 `json:"count"`
+	Bytes int64 `json:"bytes"`
+}
+
+// TrafficMetrics counts what a game sends to its players, per message type.
+// Sizes are measured before any websocket compression.
+type TrafficMetrics struct {
+	mu   sync.Mutex
+	sent map[messages.MessageType]*MessageStats
+}
+
+func NewTrafficMetrics() *TrafficMetrics {
+	return &TrafficMetrics{
+		sent: make(map[messages.MessageType]*MessageStats),
+	}
+}
+
+func (m *TrafficMetrics) RecordSent(messageType messages.MessageType, bytes int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats, exists := m.sent[messageType]
+	if !exists {
+		stats = &MessageStats{}
+		m.sent[messageType] = stats
+	}
+	stats.Count++
+	stats.Bytes += int64(bytes)
+}
+
+// Snapshot returns a copy of the counters that is safe to hand out
+func (m *TrafficMetrics) Snapshot() map[messages.MessageType]MessageStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make(map[messages.MessageType]MessageStats, len(m.sent))
+	for messageType, stats := range m.sent {
+		snapshot[messageType] = *stats
+	}
+	return snapshot
+}
+
+func (g *Game) Metrics() *TrafficMetrics {
+	return g.metrics
+}
@@ -0,0 +1,67 @@
+package game
+
+import (
+	"slices"
+	"time"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/envs"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/models"
+)
+
+// updatePause pauses the game while a player it needs is missing and resumes
+// it once nobody it needs is, reporting whether anything changed. Callers must
+// be the Run goroutine or hold connMu.
+func (g *Game) updatePause(reason models.PauseReason) bool {
+	return updatePause(&g.state, g.manager.config, reason)
+}
+
+func updatePause(state *models.GameState, config envs.GameConfig, reason models.PauseReason) bool {
+	phase := state.Phase
+	if phase == models.Paused {
+		phase = state.ResumePhase
+	}
+	if phase == models.Setup || phase == models.ReadyCheck || phase == models.GameOver {
+		return false
+	}
+
+	graceSeconds := int64(config.AbandonGrace / time.Second)
+	waiting := []models.WaitingPlayer{}
+	for _, i := range state.WaitingOn() {
+		if config.PausePolicy == envs.PauseWhenNeeded && !state.NeedsPlayer(i, phase) {
+			continue
+		}
+		player := state.Players[i]
+		waiting = append(waiting, models.WaitingPlayer{
+			Index:              i,
+			DisconnectedAtUnix: player.DisconnectedAtUnix,
+			VoteOpensAtUnix:    player.DisconnectedAtUnix + graceSeconds,
+		})
+	}
+
+	if len(waiting) == 0 {
+		if state.Phase != models.Paused {
+			return false
+		}
+		state.Phase = state.ResumePhase
+		state.ResumePhase = ""
+		state.ContinueVotes = nil
+		state.Pause = nil
+		return true
+	}
+
+	changed := false
+	if state.Phase != models.Paused {
+		state.ResumePhase = state.Phase
+		state.Phase = models.Paused
+		changed = true
+	}
+	if state.Pause == nil {
+		state.Pause = &models.PauseInfo{Reason: reason, SinceUnix: time.Now().Unix()}
+		changed = true
+	}
+	if !slices.Equal(state.Pause.WaitingFor, waiting) {
+		state.Pause.WaitingFor = waiting
+		changed = true
+	}
+	return changed
+}
@@ -0,0 +1,191 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/messages"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/models"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/repository"
+)
+
+const (
+	EventPlayerJoined      = "player_joined"
+	EventPlayerLeft        = "player_left"
+	EventActionApplied     = "action_applied"
+	EventReadyCheckExpired = "ready_check_expired"
+)
+
+const (
+	// persistDelay is how long the writer waits for more changes before saving,
+	// so a burst of chat costs one snapshot instead of one each
+	persistDelay = 200 * time.Millisecond
+	// maxEvents is how much of a game's log is kept. The log is trimmed back to
+	// it once it grows to twice that.
+	maxEvents = 500
+)
+
+// persist queues an event for the game's log and the snapshot that follows
+// it. The writes happen on the game's persister, so a slow disk never holds
+// up the game, and a crash loses at most the last moment of play. Chat isn't
+// logged, it only lives in the snapshot.
+func (g *Game) persist(eventType string, data any, snapshot GameSnapshot) {
+	if message, ok := data.(messages.ActionMessage); ok && message.Action == models.ActionChatSend {
+		g.persister.queue(nil, snapshot)
+		return
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		fmt.Println("Failed to encode event for game", g.ID, err)
+		return
+	}
+	g.persister.queue(&repository.Event{Type: eventType, At: time.Now(), Data: raw}, snapshot)
+}
+
+// persister writes a game's events and snapshots off the game loop. Only the
+// latest snapshot is kept while waiting, older ones would be overwritten anyway.
+type persister struct {
+	store repository.GameStore
+	id    string
+	wake  chan struct{}
+	quit  chan struct{}
+
+	mu       sync.Mutex
+	events   []repository.Event
+	snapshot *GameSnapshot
+
+	writeMu  sync.Mutex // Held for a whole write, so stop waits for the one in progress
+	stopped  bool
+	logged   int // Events in the stored log, as far as we know
+	stopOnce sync.Once
+}
+
+func newPersister(store repository.GameStore, id string) *persister {
+	p := &persister{
+		store: store,
+		id:    id,
+		wake:  make(chan struct{}, 1),
+		quit:  make(chan struct{}),
+	}
+	go p.run()
+	return p
+}
+
+func (p *persister) run() {
+	// A restored game picks up its log where it was
+	if events, err := p.store.Events(p.id); err == nil {
+		p.writeMu.Lock()
+		p.logged = len(events)
+		p.writeMu.Unlock()
+	}
+
+	for {
+		select {
+		case <-p.quit:
+			return
+		case <-p.wake:
+		}
+
+		select {
+		case <-p.quit:
+			return
+		case <-time.After(persistDelay):
+		}
+		p.flush()
+	}
+}
+
+func (p *persister) queue(event *repository.Event, snapshot GameSnapshot) {
+	p.mu.Lock()
+	if event != nil {
+		p.events = append(p.events, *event)
+	}
+	p.snapshot = &snapshot
+	p.mu.Unlock()
+
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// flush writes whatever is waiting, returning the error from saving the
+// snapshot if there was one
+func (p *persister) flush() error {
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+	if p.stopped {
+		return nil
+	}
+
+	p.mu.Lock()
+	events, snapshot := p.events, p.snapshot
+	p.events, p.snapshot = nil, nil
+	p.mu.Unlock()
+
+	for _, event := range events {
+		if err := p.store.AppendEvent(p.id, event); err != nil {
+			fmt.Println("Failed to append event for game", p.id, err)
+			continue
+		}
+		p.logged++
+	}
+	if p.logged >= 2*maxEvents {
+		if err := p.store.TrimEvents(p.id, maxEvents); err != nil {
+			fmt.Println("Failed to trim events for game", p.id, err)
+		} else {
+			p.logged = maxEvents
+		}
+	}
+
+	if snapshot == nil {
+		return nil
+	}
+	err := saveSnapshot(p.store, *snapshot)
+	if err != nil {
+		fmt.Println("Failed to save game", p.id, err)
+	}
+	return err
+}
+
+// save queues a snapshot and writes it, along with anything else waiting,
+// before returning
+func (p *persister) save(snapshot GameSnapshot) error {
+	p.queue(nil, snapshot)
+	return p.flush()
+}
+
+// stop drops anything not yet written and waits for a write in progress, so
+// nothing lands in the store after the game is deleted from it
+func (p *persister) stop() {
+	p.stopOnce.Do(func() { close(p.quit) })
+	p.writeMu.Lock()
+	p.stopped = true
+	p.writeMu.Unlock()
+}
+
+func saveSnapshot(store repository.GameStore, snapshot GameSnapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	return store.SaveSnapshot(snapshot.ID, data)
+}
+
+func loadSnapshot(store repository.GameStore, id string) (GameSnapshot, error) {
+	var snapshot GameSnapshot
+	data, err := store.LoadSnapshot(id)
+	if err != nil {
+		return snapshot, err
+	}
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return snapshot, err
+	}
+	if snapshot.ID == "" {
+		snapshot.ID = id
+	}
+	return snapshot, nil
+}
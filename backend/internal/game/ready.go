@@ -0,0 +1,102 @@
+package game
+
+import (
+	"time"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/messages"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/models"
+)
+
+// startReadyCheck asks every player to confirm they're ready. Whoever hasn't
+// by the deadline is removed from the lobby. The host can run it again while
+// one is going or after it expired, which starts over with a new deadline.
+func (g *Game) startReadyCheck(message messages.ActionMessage) *messages.ActionErrorMessage {
+	if message.SenderID != g.HostID {
+		return actionError(message, messages.ErrorCodeNotHost, nil)
+	}
+	if errorMessage := g.requirePhase(message, models.Setup, models.ReadyCheck); errorMessage != nil {
+		return errorMessage
+	}
+	if count := len(g.state.Players); count < 5 || count > 10 {
+		return actionError(message, messages.ErrorCodeInvalidPlayerCount, messages.ActionErrorParams{
+			"min":   5,
+			"max":   10,
+			"count": count,
+		})
+	}
+
+	timeout := g.manager.config.ReadyTimeout
+	now := time.Now()
+
+	g.connMu.Lock()
+	g.state.Phase = models.ReadyCheck
+	g.state.ReadyCheck = &models.ReadyCheckInfo{
+		StartedAtUnix: now.Unix(),
+		DeadlineUnix:  now.Add(timeout).Unix(),
+	}
+	for i := range g.state.Players {
+		// Asking is as good as confirming for the host
+		g.state.Players[i].IsReady = g.state.Players[i].ID == g.HostID
+	}
+	g.connMu.Unlock()
+
+	g.readyCheckSeq++
+	seq := g.readyCheckSeq
+	time.AfterFunc(timeout, func() {
+		select {
+		case g.readyExpired <- seq:
+		case <-g.done:
+		}
+	})
+
+	return nil
+}
+
+func (g *Game) setReady(message messages.ActionMessage) *messages.ActionErrorMessage {
+	if errorMessage := g.requirePhase(message, models.ReadyCheck); errorMessage != nil {
+		return errorMessage
+	}
+	if message.Vote == nil {
+		return actionError(message, messages.ErrorCodeMissingVote, nil)
+	}
+
+	g.connMu.Lock()
+	defer g.connMu.Unlock()
+	if player := g.state.GetPlayerByID(message.SenderID); player != nil {
+		player.IsReady = *message.Vote
+	}
+	return nil
+}
+
+// expireReadyCheck removes everyone who didn't confirm in time. Enough players
+// left over can be started straight away, or checked again by the host,
+// otherwise it's back to the lobby. Only called from Run.
+func (g *Game) expireReadyCheck(seq int) {
+	// A later ready check, or the game starting, makes this timer stale
+	if seq != g.readyCheckSeq || g.state.Phase != models.ReadyCheck {
+		return
+	}
+
+	g.connMu.RLock()
+	notReady := []string{}
+	for _, player := range g.state.Players {
+		if !player.IsReady {
+			notReady = append(notReady, player.ID)
+		}
+	}
+	g.connMu.RUnlock()
+
+	for _, id := range notReady {
+		g.removeFromLobby(id, "You were removed from the lobby for not confirming you were ready")
+	}
+
+	g.connMu.Lock()
+	g.state.ReadyCheck = nil
+	if len(g.state.Players) < 5 {
+		g.state.EndReadyCheck()
+	}
+	g.connMu.Unlock()
+
+	g.broadcastGameState()
+	g.persist(EventReadyCheckExpired, map[string]int{"removed": len(notReady)}, g.takeSnapshot())
+}
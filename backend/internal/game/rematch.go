@@ -0,0 +1,42 @@
+package game
+
+import (
+	"github.com/VincentZhao12/secret-hitler/backend/internal/messages"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/models"
+)
+
+// rematch starts another game at the same table when the host asks, or once
+// most of the players still here have voted for one
+func (g *Game) rematch(message messages.ActionMessage) *messages.ActionErrorMessage {
+	if errorMessage := g.requirePhase(message, models.GameOver); errorMessage != nil {
+		return errorMessage
+	}
+
+	if message.SenderID != g.HostID {
+		index, exists := g.state.PlayerIndexMap[message.SenderID]
+		if !exists {
+			return actionError(message, messages.ErrorCodeUnknownPlayer, nil)
+		}
+		yes := message.Vote == nil || *message.Vote
+		if !g.state.RematchVote(index, yes) {
+			return nil
+		}
+	}
+
+	// The summary is normally written as the game ends, this covers a failed write
+	g.archiveIfOver()
+
+	g.connMu.Lock()
+	g.state.Rematch()
+	// Anyone who already left gets the usual lobby grace period to come back
+	for _, player := range g.state.Players {
+		if !player.IsConnected {
+			g.scheduleRemovePlayer(player.ID)
+		}
+	}
+	g.connMu.Unlock()
+
+	g.resetHistory()
+	g.archived = false
+	return nil
+}
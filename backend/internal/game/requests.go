@@ -0,0 +1,50 @@
+package game
+
+import "github.com/VincentZhao12/secret-hitler/backend/internal/messages"
+
+// How many request IDs are remembered per player for deduplicating retries
+const maxRememberedRequests = 32
+
+// requestLog remembers the outcome of a player's most recent actions so that
+// a retried action is answered again instead of being applied twice. Only the
+// outcome is kept, not the state reply, since that would be stale by the time
+// of the retry. It is only touched from Run, so it needs no locking.
+type requestLog struct {
+	order    []string
+	outcomes map[string]requestOutcome
+}
+
+// requestOutcome is how an action turned out: its error if it failed, and for
+// an investigation who was investigated, so a retry shows the result again
+type requestOutcome struct {
+	failure           *messages.ActionErrorMessage
+	investigatedIndex int // -1 unless the action was a successful investigation
+}
+
+func (g *Game) requestLogFor(playerID string) *requestLog {
+	log, exists := g.requests[playerID]
+	if !exists {
+		log = &requestLog{outcomes: make(map[string]requestOutcome)}
+		g.requests[playerID] = log
+	}
+	return log
+}
+
+func (l *requestLog) get(requestID string) (requestOutcome, bool) {
+	outcome, exists := l.outcomes[requestID]
+	return outcome, exists
+}
+
+func (l *requestLog) remember(requestID string, outcome requestOutcome) {
+	if _, exists := l.outcomes[requestID]; exists {
+		return
+	}
+
+	l.order = append(l.order, requestID)
+	l.outcomes[requestID] = outcome
+
+	if len(l.order) > maxRememberedRequests {
+		delete(l.outcomes, l.order[0])
+		l.order = l.order[1:]
+	}
+}
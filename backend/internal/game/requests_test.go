@@ -0,0 +1,79 @@
+package game
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/messages"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/models"
+)
+
+// replyTo waits for the state sent in reply to a request
+func replyTo(t *testing.T, client *recordingClient, requestID string, nth int) *messages.GameStateMessage {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		client.mu.Lock()
+		seen := 0
+		for _, message := range client.sent {
+			if state, ok := message.(*messages.GameStateMessage); ok && state.RequestID == requestID {
+				seen++
+				if seen == nth {
+					client.mu.Unlock()
+					return state
+				}
+			}
+		}
+		client.mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("no reply %d to %s", nth, requestID)
+	return nil
+}
+
+func TestRetriedInvestigationShowsResultAgain(t *testing.T) {
+	g := newTestGame(t)
+	roles := []models.PlayerRole{models.RoleLiberal, models.RoleFascist, models.RoleHitler, models.RoleLiberal, models.RoleLiberal}
+	for i, role := range roles {
+		g.state.Players[i].Role = role
+	}
+	g.state.Phase = models.Executive
+	g.state.PresidentIndex = 0
+
+	// Everyone is here, so the game doesn't pause
+	client := &recordingClient{}
+	for i := range roles {
+		conn := &recordingClient{}
+		if i == 0 {
+			conn = client
+		}
+		if err := g.AddConnection(fmt.Sprintf("id%d", i), conn, ConnectionOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	investigate := func(target int) {
+		g.Submit(messages.ActionMessage{
+			BaseMessage: messages.BaseMessage{Type: messages.MessageTypeAction, SenderID: "id0", RequestID: "req-1"},
+			Action:      models.ActionInvestigate,
+			TargetIndex: target,
+		})
+	}
+
+	investigate(1)
+	first := replyTo(t, client, "req-1", 1)
+	// The retry names someone else, which must not reveal them
+	investigate(2)
+	retry := replyTo(t, client, "req-1", 2)
+
+	for name, reply := range map[string]*messages.GameStateMessage{"first reply": first, "retry": retry} {
+		players := reply.GameState.Players
+		if players[1].Role != models.RoleFascist {
+			t.Errorf("%s shows the investigated player as %s, want %s", name, players[1].Role, models.RoleFascist)
+		}
+		if players[2].Role != models.RoleHidden {
+			t.Errorf("%s reveals player 2 as %s", name, players[2].Role)
+		}
+	}
+}
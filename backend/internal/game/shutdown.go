@@ -0,0 +1,77 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+)
+
+// BeginShutdown stops the janitor, the matchmaking queue and the manager from
+// accepting new games
+func (m *Manager) BeginShutdown() {
+	m.stopJanitor()
+	m.stopMatchmaking()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.shuttingDown = true
+}
+
+func (m *Manager) IsShuttingDown() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.shuttingDown
+}
+
+func (m *Manager) allGames() []*Game {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	games := make([]*Game, 0, len(m.Games))
+	for _, game := range m.Games {
+		games = append(games, game)
+	}
+	return games
+}
+
+// NotifyRestart tells every connected player that the server is restarting
+func (m *Manager) NotifyRestart() {
+	for _, game := range m.allGames() {
+		game.broadcastRestart()
+	}
+}
+
+// SaveGames writes a final snapshot of every game to the store, along with
+// anything their persisters had not written yet. One game failing doesn't stop
+// the rest being saved, the failures are returned together.
+func (m *Manager) SaveGames() error {
+	var errs []error
+	for _, game := range m.allGames() {
+		if err := game.persister.save(game.Snapshot()); err != nil {
+			errs = append(errs, fmt.Errorf("game %s: %w", game.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RestoreGames brings back every game in the store. Games stay stored until
+// they are removed, so a crash right after booting loses nothing.
+func (m *Manager) RestoreGames() (int, error) {
+	ids, err := m.store.ListSnapshots()
+	if err != nil {
+		return 0, err
+	}
+
+	restored := 0
+	for _, id := range ids {
+		snapshot, err := loadSnapshot(m.store, id)
+		if err != nil {
+			fmt.Println("Skipping unreadable game", id, err)
+			continue
+		}
+
+		m.AddGame(RestoreGame(m, snapshot))
+		restored++
+	}
+
+	return restored, nil
+}
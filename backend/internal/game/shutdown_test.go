@@ -0,0 +1,52 @@
+package game
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/envs"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/repository"
+)
+
+var errDiskFull = errors.New("disk full")
+
+// failingStore refuses snapshots of one game
+type failingStore struct {
+	*repository.MemoryStore
+	failID string
+}
+
+func (s *failingStore) SaveSnapshot(id string, snapshot []byte) error {
+	if id == s.failID {
+		return errDiskFull
+	}
+	return s.MemoryStore.SaveSnapshot(id, snapshot)
+}
+
+func TestSaveGamesSavesEveryGame(t *testing.T) {
+	store := &failingStore{MemoryStore: repository.NewMemoryStore()}
+	m := NewManager(store, envs.GameConfig{AbandonGrace: time.Minute, ReadyTimeout: time.Minute, LobbyGrace: time.Minute})
+
+	ids := []string{}
+	for range 5 {
+		g := NewGame(m)
+		t.Cleanup(g.Close)
+		m.AddGame(g)
+		ids = append(ids, g.ID)
+	}
+	store.failID = ids[2]
+
+	err := m.SaveGames()
+	if !errors.Is(err, errDiskFull) {
+		t.Errorf("SaveGames() = %v, want %v", err, errDiskFull)
+	}
+	for _, id := range ids {
+		if id == store.failID {
+			continue
+		}
+		if _, err := store.LoadSnapshot(id); err != nil {
+			t.Errorf("game %s wasn't saved: %v", id, err)
+		}
+	}
+}
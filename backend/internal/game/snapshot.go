@@ -0,0 +1,97 @@
+package game
+
+import (
+	"time"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/models"
+)
+
+const snapshotTimeout = 5 * time.Second
+
+// GameSnapshot is everything needed to bring a game back after a restart
+type GameSnapshot struct {
+	ID         string           `json:"id"`
+	State      models.GameState `json:"state"`
+	Streamer   StreamerOptions  `json:"streamer"`
+	Access     Access           `json:"access"`
+	Visibility Visibility       `json:"visibility"`
+	CreatedAt  time.Time        `json:"created_at"`
+	TakenAt    time.Time        `json:"taken_at"`
+}
+
+// Snapshot captures the game between two actions
+func (g *Game) Snapshot() GameSnapshot {
+	reply := make(chan GameSnapshot, 1)
+
+	select {
+	case g.snapshotReq <- reply:
+		return <-reply
+	case <-g.done:
+		return g.takeSnapshot()
+	case <-time.After(snapshotTimeout):
+		// Run has stopped, so nothing is changing the state anymore
+		return g.takeSnapshot()
+	}
+}
+
+func (g *Game) takeSnapshot() GameSnapshot {
+	g.connMu.RLock()
+	defer g.connMu.RUnlock()
+
+	g.activityMu.Lock()
+	visibility, createdAt := g.visibility, g.createdAt
+	g.activityMu.Unlock()
+
+	return GameSnapshot{
+		ID:         g.ID,
+		State:      g.state.Clone(),
+		Streamer:   g.StreamerOptions(),
+		Access:     g.accessSnapshot(),
+		Visibility: visibility,
+		CreatedAt:  createdAt,
+		TakenAt:    time.Now(),
+	}
+}
+
+// RestoreGame recreates a game from a snapshot. Nobody is connected yet, so a
+// game that was in progress comes back paused until its players reconnect.
+func RestoreGame(manager *Manager, snapshot GameSnapshot) *Game {
+	state := snapshot.State
+	state.RebuildPlayerIndex()
+
+	// Everyone gets a full grace period to come back after the restart
+	now := time.Now().Unix()
+	for i := range state.Players {
+		state.Players[i].IsConnected = false
+		state.Players[i].DisconnectedAtUnix = now
+	}
+	state.Pause = nil
+	// The ready check timer didn't survive the restart, so start over
+	if state.Phase == models.ReadyCheck {
+		state.EndReadyCheck()
+	}
+	updatePause(&state, manager.config, models.PauseReasonServerRestart)
+
+	g := newGame(manager, snapshot.ID, state)
+	g.archived = state.Phase == models.GameOver
+	g.SetStreamerOptions(snapshot.Streamer)
+	g.access = snapshot.Access
+	if !snapshot.CreatedAt.IsZero() {
+		g.activityMu.Lock()
+		g.createdAt = snapshot.CreatedAt
+		g.activityMu.Unlock()
+	}
+	// Anyone who doesn't reconnect to a lobby loses their seat as usual
+	if state.InLobby() {
+		g.connMu.Lock()
+		for _, player := range state.Players {
+			g.scheduleRemovePlayer(player.ID)
+		}
+		g.connMu.Unlock()
+	}
+	// Snapshots from before visibility existed are private
+	if visibility, err := ParseVisibility(string(snapshot.Visibility)); err == nil {
+		g.SetVisibility(visibility)
+	}
+	return g
+}
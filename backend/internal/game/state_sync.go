@@ -0,0 +1,137 @@
+package game
+
+import (
+	"encoding/json"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/messages"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/views"
+)
+
+// Every this many patches a full snapshot is sent so clients can't drift forever
+const fullSnapshotInterval = 50
+
+// ConnectionOptions are negotiated per connection when a player connects
+type ConnectionOptions struct {
+	DeltaUpdates bool
+	Encoding     messages.Encoding
+}
+
+// stateStream tracks what a single player has been sent so that following
+// updates can be sent as patches against it
+type stateStream struct {
+	opts                 ConnectionOptions
+	seq                  uint64
+	last                 any
+	patchesSinceSnapshot int
+}
+
+// resetStream starts a fresh stream for a player, the next state sent to them
+// is a full snapshot
+func (g *Game) resetStream(id string, opts ConnectionOptions) {
+	g.sendMu.Lock()
+	defer g.sendMu.Unlock()
+	g.streams[id] = &stateStream{opts: opts}
+}
+
+// Resync forgets what a player has been sent and sends them a full snapshot
+func (g *Game) Resync(id string) {
+	g.connMu.RLock()
+	conn, exists := g.Connections[id]
+	g.connMu.RUnlock()
+
+	if !exists || conn == nil {
+		return
+	}
+
+	g.sendMu.Lock()
+	if stream, exists := g.streams[id]; exists {
+		stream.last = nil
+	}
+	g.sendMu.Unlock()
+
+	g.sendState(id, conn, views.ForPlayer(&g.state, id), "")
+}
+
+// sendState sends a player their view of the game, as a patch when they
+// negotiated delta updates and a patch is worth it. A non-empty requestID marks
+// the update as the reply to that action, so it is sent even if nothing changed.
+func (g *Game) sendState(id string, conn Client, state views.PlayerView, requestID string) error {
+	g.sendMu.Lock()
+	defer g.sendMu.Unlock()
+
+	stream, exists := g.streams[id]
+	if !exists {
+		stream = &stateStream{}
+		g.streams[id] = stream
+	}
+
+	if !stream.opts.DeltaUpdates {
+		stream.seq++
+		message := messages.NewGameStateMessage("server", state)
+		message.Seq = stream.seq
+		message.RequestID = requestID
+		return g.send(conn, message)
+	}
+
+	full, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	var doc any
+	if err := json.Unmarshal(full, &doc); err != nil {
+		return err
+	}
+
+	if stream.last != nil && stream.patchesSinceSnapshot < fullSnapshotInterval {
+		patch := messages.Diff(stream.last, doc)
+		if len(patch) == 0 && requestID == "" {
+			return nil
+		}
+
+		patchMessage := messages.NewGameStatePatchMessage("server", stream.seq+1, patch)
+		patchMessage.RequestID = requestID
+		encoded, err := json.Marshal(patchMessage)
+		if err != nil {
+			return err
+		}
+
+		// A patch bigger than the state itself is better sent as a snapshot
+		if len(encoded) < len(full) {
+			if err := g.send(conn, patchMessage); err != nil {
+				return err
+			}
+			stream.seq++
+			stream.last = doc
+			stream.patchesSinceSnapshot++
+			return nil
+		}
+	}
+
+	message := messages.NewGameStateMessage("server", state)
+	message.Seq = stream.seq + 1
+	message.RequestID = requestID
+	if err := g.send(conn, message); err != nil {
+		return err
+	}
+	stream.seq++
+	stream.last = doc
+	stream.patchesSinceSnapshot = 0
+	return nil
+}
+
+// sendMessage writes any other message to a player, serialized with state updates
+func (g *Game) sendMessage(conn Client, message messages.Message) error {
+	g.sendMu.Lock()
+	defer g.sendMu.Unlock()
+	return g.send(conn, message)
+}
+
+// send writes a message and records its size. sendMu must be held.
+func (g *Game) send(conn Client, message messages.Message) error {
+	size, err := conn.Send(message)
+	if err != nil {
+		return err
+	}
+	g.metrics.RecordSent(message.GetType(), size)
+	return nil
+}
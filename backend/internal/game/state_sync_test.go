@@ -0,0 +1,156 @@
+package game
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/envs"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/messages"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/models"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/repository"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/views"
+)
+
+// recordingClient keeps every message sent to it
+type recordingClient struct {
+	mu     sync.Mutex
+	sent   []messages.Message
+	closed bool
+}
+
+func (c *recordingClient) Send(message messages.Message) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sent = append(c.sent, message)
+	return 1, nil
+}
+
+func (c *recordingClient) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	return nil
+}
+
+func (c *recordingClient) isClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+func newTestGame(t *testing.T) *Game {
+	t.Helper()
+	m := NewManager(repository.NewMemoryStore(), envs.GameConfig{
+		AbandonGrace: time.Minute,
+		ReadyTimeout: time.Minute,
+		LobbyGrace:   time.Minute,
+	})
+	g := NewGame(m)
+	t.Cleanup(g.Close)
+
+	for i := range 5 {
+		if _, err := g.state.AddPlayer(fmt.Sprintf("id%d", i), fmt.Sprintf("player%d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return g
+}
+
+// sequence returns the sequence number and whether the message was a patch
+func sequence(t *testing.T, message messages.Message) (uint64, bool) {
+	t.Helper()
+	switch m := message.(type) {
+	case *messages.GameStateMessage:
+		return m.Seq, false
+	case *messages.GameStatePatchMessage:
+		return m.Seq, true
+	}
+	t.Fatalf("unexpected %T", message)
+	return 0, false
+}
+
+func TestSendStateSequence(t *testing.T) {
+	g := newTestGame(t)
+	client := &recordingClient{}
+	g.resetStream("id0", ConnectionOptions{DeltaUpdates: true})
+
+	chat := func(text string) {
+		g.state.ChatHistory = append(g.state.ChatHistory, models.ChatEntry{SenderID: "id1", SenderName: "player1", Text: text})
+		if err := g.sendState("id0", client, views.ForPlayer(&g.state, "id0"), ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	chat("first")
+	chat("second")
+	// Nothing changed, so nothing is sent
+	if err := g.sendState("id0", client, views.ForPlayer(&g.state, "id0"), ""); err != nil {
+		t.Fatal(err)
+	}
+	// Unless it answers an action
+	if err := g.sendState("id0", client, views.ForPlayer(&g.state, "id0"), "req-1"); err != nil {
+		t.Fatal(err)
+	}
+	for i := range fullSnapshotInterval {
+		chat(fmt.Sprint(i))
+	}
+
+	if len(client.sent) != 3+fullSnapshotInterval {
+		t.Fatalf("got %d messages, want %d", len(client.sent), 3+fullSnapshotInterval)
+	}
+	for i, message := range client.sent {
+		seq, patch := sequence(t, message)
+		if seq != uint64(i+1) {
+			t.Errorf("message %d has seq %d, want %d", i, seq, i+1)
+		}
+		// The first message and every one after fullSnapshotInterval patches is
+		// a full snapshot
+		wantPatch := i%(fullSnapshotInterval+1) != 0
+		if patch != wantPatch {
+			t.Errorf("message %d: patch = %v, want %v", i, patch, wantPatch)
+		}
+	}
+	if reply, ok := client.sent[2].(*messages.GameStatePatchMessage); !ok || reply.RequestID != "req-1" || len(reply.Patch) != 0 {
+		t.Errorf("reply to req-1 should be an empty patch, got %+v", client.sent[2])
+	}
+}
+
+func TestSendStateWithoutDeltas(t *testing.T) {
+	g := newTestGame(t)
+	client := &recordingClient{}
+	g.resetStream("id0", ConnectionOptions{})
+
+	for range 3 {
+		if err := g.sendState("id0", client, views.ForPlayer(&g.state, "id0"), ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i, message := range client.sent {
+		if seq, patch := sequence(t, message); patch || seq != uint64(i+1) {
+			t.Errorf("message %d: seq %d patch %v, want a full state with seq %d", i, seq, patch, i+1)
+		}
+	}
+}
+
+func TestResyncSendsFullState(t *testing.T) {
+	g := newTestGame(t)
+	client := &recordingClient{}
+	g.Connections["id0"] = client
+	g.resetStream("id0", ConnectionOptions{DeltaUpdates: true})
+
+	if err := g.sendState("id0", client, views.ForPlayer(&g.state, "id0"), ""); err != nil {
+		t.Fatal(err)
+	}
+	g.Resync("id0")
+
+	if len(client.sent) != 2 {
+		t.Fatalf("got %d messages, want 2", len(client.sent))
+	}
+	// The numbering carries on, so the client can tell nothing was missed
+	if seq, patch := sequence(t, client.sent[1]); patch || seq != 2 {
+		t.Errorf("resync sent seq %d patch %v, want a full state with seq 2", seq, patch)
+	}
+}
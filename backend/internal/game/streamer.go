@@ -0,0 +1,125 @@
+package game
+
+import (
+	"time"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/models"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/views"
+)
+
+const defaultStreamerDelay = 2 * time.Minute
+
+// StreamerOptions configures the delayed full-reveal spectator feed. The feed
+// only ever shows states that are at least DelaySeconds old and at least
+// DelayRounds rounds behind the live game.
+type StreamerOptions struct {
+	Enabled      bool `json:"enabled"`
+	DelaySeconds int  `json:"delay_seconds"`
+	DelayRounds  int  `json:"delay_rounds"`
+}
+
+type stateSnapshot struct {
+	state   views.StreamerView
+	takenAt time.Time
+	seq     int
+}
+
+func (g *Game) SetStreamerOptions(opts StreamerOptions) {
+	if opts.DelaySeconds < 0 {
+		opts.DelaySeconds = 0
+	}
+	if opts.DelayRounds < 0 {
+		opts.DelayRounds = 0
+	}
+	// A feed without any delay would leak the live game
+	if opts.Enabled && opts.DelaySeconds == 0 && opts.DelayRounds == 0 {
+		opts.DelaySeconds = int(defaultStreamerDelay / time.Second)
+	}
+
+	g.historyMu.Lock()
+	defer g.historyMu.Unlock()
+	g.streamer = opts
+}
+
+func (g *Game) StreamerOptions() StreamerOptions {
+	g.historyMu.Lock()
+	defer g.historyMu.Unlock()
+	return g.streamer
+}
+
+// recordSnapshot appends the current state to the streamer history and drops
+// snapshots the feed can no longer reach
+func (g *Game) recordSnapshot() {
+	g.historyMu.Lock()
+	defer g.historyMu.Unlock()
+
+	if !g.streamer.Enabled {
+		return
+	}
+
+	g.historySeq++
+	g.history = append(g.history, stateSnapshot{
+		state:   views.ForStreamer(&g.state),
+		takenAt: time.Now(),
+		seq:     g.historySeq,
+	})
+
+	// Everything before the newest visible snapshot will never be shown again
+	if visible := g.visibleSnapshotIndex(time.Now()); visible > 0 {
+		g.history = append([]stateSnapshot(nil), g.history[visible:]...)
+	}
+}
+
+// resetHistory forgets the snapshots of a finished match, so the delays start
+// over for a rematch. The sequence keeps counting, a spectator who saw the end
+// of the last match still has to notice the first state of the next one.
+func (g *Game) resetHistory() {
+	g.historyMu.Lock()
+	defer g.historyMu.Unlock()
+	g.history = nil
+}
+
+// visibleSnapshotIndex returns the index of the newest snapshot that satisfies
+// both delays, or -1 if none does yet. historyMu must be held.
+func (g *Game) visibleSnapshotIndex(now time.Time) int {
+	if len(g.history) == 0 {
+		return -1
+	}
+
+	cutoff := now.Add(-time.Duration(g.streamer.DelaySeconds) * time.Second)
+	maxRound := g.history[len(g.history)-1].state.Round - g.streamer.DelayRounds
+
+	for i := len(g.history) - 1; i >= 0; i-- {
+		snapshot := g.history[i]
+		if snapshot.takenAt.After(cutoff) || snapshot.state.Round > maxRound {
+			continue
+		}
+		return i
+	}
+
+	return -1
+}
+
+// DelayedState returns the state the streamer feed is allowed to show along
+// with a sequence number that changes whenever that state does. Once the game
+// is over nothing is hidden anymore, so the final state is shown at once.
+func (g *Game) DelayedState() (views.StreamerView, int, bool) {
+	g.historyMu.Lock()
+	defer g.historyMu.Unlock()
+
+	if !g.streamer.Enabled || len(g.history) == 0 {
+		return views.StreamerView{}, 0, false
+	}
+
+	latest := g.history[len(g.history)-1]
+	if latest.state.Phase == models.GameOver {
+		return latest.state, latest.seq, true
+	}
+
+	visible := g.visibleSnapshotIndex(time.Now())
+	if visible < 0 {
+		return views.StreamerView{}, 0, false
+	}
+
+	return g.history[visible].state, g.history[visible].seq, true
+}
@@ -0,0 +1,51 @@
+package game
+
+import (
+	"github.com/VincentZhao12/secret-hitler/backend/internal/messages"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/models"
+)
+
+func actionError(message messages.ActionMessage, code messages.ActionErrorCode, params messages.ActionErrorParams) *messages.ActionErrorMessage {
+	return messages.NewActionErrorMessage(message.SenderID, message.Action, code, params)
+}
+
+func (g *Game) validateActionMessage(message messages.ActionMessage, requiresPresident bool, requiresTarget bool) *messages.ActionErrorMessage {
+	president := g.state.GetPlayer(g.state.PresidentIndex)
+	if requiresPresident && (president == nil || president.ID != message.SenderID) {
+		return actionError(message, messages.ErrorCodeNotYourTurn, nil)
+	}
+
+	if requiresTarget && (message.TargetIndex < 0 || message.TargetIndex >= len(g.state.Players)) {
+		return actionError(message, messages.ErrorCodeInvalidTarget, messages.ActionErrorParams{
+			"target_index": message.TargetIndex,
+		})
+	}
+
+	targetPlayer := g.state.GetPlayer(message.TargetIndex)
+	if requiresTarget && (targetPlayer == nil || targetPlayer.IsExecuted) {
+		return actionError(message, messages.ErrorCodeTargetExecuted, messages.ActionErrorParams{
+			"target_index": message.TargetIndex,
+		})
+	}
+
+	sender := g.state.GetPlayerByID(message.SenderID)
+	if sender != nil && sender.IsExecuted {
+		return actionError(message, messages.ErrorCodePlayerExecuted, nil)
+	}
+
+	return nil
+}
+
+// requirePhase refuses the action unless the game is in one of the given phases
+func (g *Game) requirePhase(message messages.ActionMessage, phases ...models.GamePhase) *messages.ActionErrorMessage {
+	for _, phase := range phases {
+		if g.state.Phase == phase {
+			return nil
+		}
+	}
+
+	return actionError(message, messages.ErrorCodeWrongPhase, messages.ActionErrorParams{
+		"expected": phases,
+		"actual":   g.state.Phase,
+	})
+}
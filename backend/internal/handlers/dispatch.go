@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/game"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/messages"
+)
+
+var (
+	errMalformedMessage      = errors.New("malformed message")
+	errUnexpectedMessageType = errors.New("unexpected message type")
+)
+
+// dispatch hands a raw client message to its game. Every transport goes
+// through here so a message is handled the same way however it arrived.
+func dispatch(g *game.Game, playerId string, messageBytes []byte) error {
+	var envelope struct {
+		Base messages.BaseMessage `json:"base_message"`
+	}
+	if err := json.Unmarshal(messageBytes, &envelope); err != nil {
+		return errMalformedMessage
+	}
+
+	switch envelope.Base.GetType() {
+	case messages.MessageTypeAction:
+		var action messages.ActionMessage
+		if err := json.Unmarshal(messageBytes, &action); err != nil {
+			return errMalformedMessage
+		}
+		// Actions always come from the player the connection belongs to,
+		// whatever the client put in the message
+		action.SenderID = playerId
+		return g.Submit(action)
+	case messages.MessageTypeResync:
+		g.Resync(playerId)
+	default:
+		return errUnexpectedMessageType
+	}
+
+	return nil
+}
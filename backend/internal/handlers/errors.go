@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/repository"
+)
+
+// ErrorResponse is the JSON body of errors clients are expected to act on,
+// with a stable code alongside the readable message
+type ErrorResponse struct {
+	Code    string         `json:"code"`
+	Message string         `json:"message"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+const (
+	ErrorCodeInvalidUsername  = "invalid_username"
+	ErrorCodeReservedUsername = "reserved_username"
+	ErrorCodeUsernameTaken    = "username_taken"
+	ErrorCodeGameInProgress   = "game_in_progress"
+	ErrorCodeLobbyLocked      = "lobby_locked"
+)
+
+func writeError(w http.ResponseWriter, status int, code string, err error) {
+	writeErrorDetails(w, status, code, err, nil)
+}
+
+func writeErrorDetails(w http.ResponseWriter, status int, code string, err error, details map[string]any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(ErrorResponse{Code: code, Message: err.Error(), Details: details})
+}
+
+// writeUsernameError answers a rejected username and reports whether err was one
+func writeUsernameError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, repository.ErrUsernameTaken):
+		writeError(w, http.StatusConflict, ErrorCodeUsernameTaken, err)
+	case errors.Is(err, repository.ErrReservedUsername):
+		writeError(w, http.StatusUnprocessableEntity, ErrorCodeReservedUsername, err)
+	case errors.Is(err, repository.ErrInvalidUsername):
+		writeError(w, http.StatusUnprocessableEntity, ErrorCodeInvalidUsername, err)
+	default:
+		return false
+	}
+	return true
+}
@@ -2,24 +2,74 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/VincentZhao12/secret-hitler/backend/internal/game"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/messages"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/repository"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/views"
+	"github.com/go-chi/chi/v5"
 )
 
+type CreateGameRequest struct {
+	StreamerEnabled      bool   `json:"streamer_enabled,omitempty"`
+	StreamerDelaySeconds int    `json:"streamer_delay_seconds,omitempty"`
+	StreamerDelayRounds  int    `json:"streamer_delay_rounds,omitempty"`
+	Password             string `json:"password,omitempty"`
+	InviteOnly           bool   `json:"invite_only,omitempty"`
+	Visibility           string `json:"visibility,omitempty"` // public or private, private by default
+}
+
 type CreateGameResponse struct {
 	GameID string `json:"game_id"`
+	Invite string `json:"invite,omitempty"` // For the creator to join an invite-only game with
 }
 
 func CreateGame(Manager *game.Manager) http.HandlerFunc {
 
 	return func(w http.ResponseWriter, r *http.Request) {
+		if Manager.IsShuttingDown() {
+			http.Error(w, "Server is restarting, try again shortly", http.StatusServiceUnavailable)
+			return
+		}
+
+		var req CreateGameRequest
+
+		// The body is optional, an empty one creates a game with defaults
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil && !errors.Is(err, io.EOF) {
+			http.Error(w, "Invalid request payload", http.StatusBadRequest)
+			return
+		}
+
+		visibility, err := game.ParseVisibility(req.Visibility)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
 		newGame := game.NewGame(Manager)
+		newGame.SetStreamerOptions(game.StreamerOptions{
+			Enabled:      req.StreamerEnabled,
+			DelaySeconds: req.StreamerDelaySeconds,
+			DelayRounds:  req.StreamerDelayRounds,
+		})
+		newGame.SetAccess(game.AccessOptions{
+			Password:   req.Password,
+			InviteOnly: req.InviteOnly,
+		})
+		newGame.SetVisibility(visibility)
 		gameID := Manager.AddGame(newGame)
 
 		resp := CreateGameResponse{
 			GameID: gameID,
 		}
+		if req.InviteOnly {
+			resp.Invite = newGame.NewInvite()
+		}
 
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusCreated) // sets 201
@@ -27,9 +77,95 @@ func CreateGame(Manager *game.Manager) http.HandlerFunc {
 	}
 }
 
+type ListGamesResponse struct {
+	Games []views.LobbyListing `json:"games"`
+}
+
+// ListGames lists the public games that are waiting for players
+func ListGames(Manager *game.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		games, _ := Manager.PublicLobbies()
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ListGamesResponse{Games: games})
+	}
+}
+
+type GameMetricsResponse struct {
+	GameID string                                     `json:"game_id"`
+	Sent   map[messages.MessageType]game.MessageStats `json:"sent"`
+}
+
+// GameMetrics reports how many messages and bytes a game has sent, per message type
+func GameMetrics(Manager *game.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		game, exists := Manager.GetGame(chi.URLParam(r, "id"))
+		if game == nil || !exists {
+			http.Error(w, "Invalid game id", http.StatusNotFound)
+			return
+		}
+
+		resp := GameMetricsResponse{
+			GameID: game.ID,
+			Sent:   game.Metrics().Snapshot(),
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}
+}
+
+type ServerMetricsResponse struct {
+	Games  int              `json:"games"`
+	Reaper game.ReaperStats `json:"reaper"`
+}
+
+func ServerMetrics(Manager *game.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resp := ServerMetricsResponse{
+			Games:  Manager.GameCount(),
+			Reaper: Manager.ReaperStats(),
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}
+}
+
+// GameSummary returns the archived summary of a finished game
+func GameSummary(Manager *game.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Rematches at the same table are picked with ?match=, the first game by default
+		match := 1
+		if param := r.URL.Query().Get("match"); param != "" {
+			parsed, err := strconv.Atoi(param)
+			if err != nil || parsed < 1 {
+				http.Error(w, "Invalid match number", http.StatusBadRequest)
+				return
+			}
+			match = parsed
+		}
+
+		summary, err := Manager.Summary(chi.URLParam(r, "id"), match)
+		if errors.Is(err, repository.ErrGameNotFound) {
+			http.Error(w, "No finished game with that id", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, "Failed to load summary", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(summary)
+	}
+}
+
 type JoinGameRequest struct {
 	GameID   string `json:"game_id"`
 	Username string `json:"username"`
+	Password string `json:"password,omitempty"`
+	Invite   string `json:"invite,omitempty"`
 }
 
 type JoinGameResponse struct {
@@ -47,13 +183,40 @@ func JoinGame(Manager *game.Manager) http.HandlerFunc {
 			return
 		}
 
+		ip := clientIP(r)
+		if !joinLimiter.Allow(ip) {
+			http.Error(w, "Too many failed attempts, try again later", http.StatusTooManyRequests)
+			return
+		}
+
 		game, exists := Manager.GetGame(req.GameID)
 		if game == nil || !exists {
 			http.Error(w, "Invalid game id", http.StatusBadRequest)
 			return
 		}
 
-		player, err := game.NewPlayer(req.Username)
+		player, err := game.Join(req.Username, req.Password, req.Invite)
+		if errors.Is(err, repository.ErrWrongPassword) || errors.Is(err, repository.ErrInviteRequired) || errors.Is(err, repository.ErrInvalidInvite) {
+			joinLimiter.Fail(ip)
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if writeUsernameError(w, err) {
+			return
+		}
+		if errors.Is(err, repository.ErrGameInProgress) {
+			// Late arrivals can still watch if the game has a spectator feed
+			var details map[string]any
+			if game.StreamerOptions().Enabled {
+				details = map[string]any{"spectate": "/api/v1/spectate?game=" + game.ID}
+			}
+			writeErrorDetails(w, http.StatusConflict, ErrorCodeGameInProgress, err, details)
+			return
+		}
+		if errors.Is(err, repository.ErrLobbyLocked) {
+			writeErrorDetails(w, http.StatusConflict, ErrorCodeLobbyLocked, err, nil)
+			return
+		}
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
@@ -69,3 +232,44 @@ func JoinGame(Manager *game.Manager) http.HandlerFunc {
 		json.NewEncoder(w).Encode(resp)   // encodes and writes JSON
 	}
 }
+
+type CreateInviteRequest struct {
+	PlayerID string `json:"player_id"`
+}
+
+type CreateInviteResponse struct {
+	GameID string `json:"game_id"`
+	Invite string `json:"invite"`
+}
+
+// CreateInvite hands the host a new single use invite for their game
+func CreateInvite(Manager *game.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		game, exists := Manager.GetGame(chi.URLParam(r, "id"))
+		if game == nil || !exists {
+			http.Error(w, "Invalid game id", http.StatusNotFound)
+			return
+		}
+
+		var req CreateInviteRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request payload", http.StatusBadRequest)
+			return
+		}
+
+		invite, err := game.NewInviteFor(req.PlayerID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+
+		resp := CreateInviteResponse{
+			GameID: game.ID,
+			Invite: invite,
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(resp)
+	}
+}
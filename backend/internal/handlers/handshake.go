@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"slices"
+	"time"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/game"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/messages"
+	"github.com/gorilla/websocket"
+)
+
+const handshakeTimeout = 10 * time.Second
+
+var errOutdatedClient = errors.New("client did not complete the handshake")
+
+const outdatedClientReason = "This version of the game is out of date, please reload the page"
+
+// handshake waits for the client's hello, answers with a welcome and returns
+// the connection options for the negotiated features. Clients that don't say
+// hello in time, or speak a version the server dropped, are told to reload.
+func handshake(conn *websocket.Conn) (game.ConnectionOptions, error) {
+	conn.SetReadDeadline(time.Now().Add(handshakeTimeout))
+	defer conn.SetReadDeadline(time.Time{})
+
+	_, messageBytes, err := conn.ReadMessage()
+	if err != nil {
+		// Clients from before the handshake never say hello, so running out of
+		// time is the one sign we get that they need to reload
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			conn.SetWriteDeadline(time.Now().Add(time.Second))
+			conn.WriteJSON(messages.NewConnectionErrorMessage("server", outdatedClientReason, messages.ConnectionErrorTypeOutdatedClient))
+			return game.ConnectionOptions{}, errOutdatedClient
+		}
+		return game.ConnectionOptions{}, err
+	}
+
+	var hello messages.HelloMessage
+	if err := json.Unmarshal(messageBytes, &hello); err != nil ||
+		hello.GetType() != messages.MessageTypeHello ||
+		!messages.IsSupportedVersion(hello.ProtocolVersion) {
+
+		conn.WriteJSON(messages.NewConnectionErrorMessage("server", outdatedClientReason, messages.ConnectionErrorTypeOutdatedClient))
+		return game.ConnectionOptions{}, errOutdatedClient
+	}
+
+	opts, features := negotiate(hello.ProtocolVersion, hello.Features)
+	if err := conn.WriteJSON(messages.NewWelcomeMessage("server", hello.ProtocolVersion, features)); err != nil {
+		return game.ConnectionOptions{}, err
+	}
+
+	return opts, nil
+}
+
+// negotiate picks the features to use for a connection on a supported version
+func negotiate(version int, requested []messages.Feature) (game.ConnectionOptions, []messages.Feature) {
+	features := messages.NegotiateFeatures(version, requested)
+	opts := game.ConnectionOptions{
+		DeltaUpdates: slices.Contains(features, messages.FeatureDeltaUpdates),
+		Encoding:     messages.EncodingJSON,
+	}
+	if slices.Contains(features, messages.FeatureMsgPack) {
+		opts.Encoding = messages.EncodingMsgPack
+	}
+	return opts, features
+}
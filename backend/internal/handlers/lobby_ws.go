@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/game"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/messages"
+)
+
+const lobbyFeedPollInterval = time.Second
+
+// LobbyFeed pushes the public game list whenever it changes, starting with the
+// current list
+func LobbyFeed(Manager *game.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrade(w, r)
+		if err != nil {
+			fmt.Println("Failed to upgrade lobby feed connection:", err)
+			return
+		}
+		defer conn.Close()
+
+		closed := waitForClose(conn)
+
+		ticker := time.NewTicker(lobbyFeedPollInterval)
+		defer ticker.Stop()
+
+		lastSeq := -1
+		for {
+			games, seq := Manager.PublicLobbies()
+			if seq != lastSeq {
+				lastSeq = seq
+				if err := conn.WriteJSON(messages.NewLobbyListMessage("server", games)); err != nil {
+					fmt.Println("error sending lobby list")
+					return
+				}
+			}
+
+			select {
+			case <-closed:
+				return
+			case <-ticker.C:
+			}
+		}
+	}
+}
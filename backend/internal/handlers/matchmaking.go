@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/game"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/messages"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/repository"
+)
+
+type EnqueueRequest struct {
+	Username      string `json:"username"`
+	PreferredSize int    `json:"preferred_size"` // Between 5 and 10
+}
+
+type EnqueueResponse struct {
+	TicketID string `json:"ticket_id"`
+}
+
+// Enqueue puts a player in the quick play queue. They learn about their table
+// by opening the matchmaking socket with the ticket.
+func Enqueue(Manager *game.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if Manager.IsShuttingDown() {
+			http.Error(w, "Server is restarting, try again shortly", http.StatusServiceUnavailable)
+			return
+		}
+
+		var req EnqueueRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request payload", http.StatusBadRequest)
+			return
+		}
+
+		ticketID, err := Manager.Enqueue(req.Username, req.PreferredSize)
+		if errors.Is(err, repository.ErrMatchmakingClosed) {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		if writeUsernameError(w, err) {
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(EnqueueResponse{TicketID: ticketID})
+	}
+}
+
+// Matchmaking waits on a ticket and sends the player their game once a table
+// is formed. Closing the socket before then leaves the queue.
+func Matchmaking(Manager *game.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrade(w, r)
+		if err != nil {
+			fmt.Println("Failed to upgrade matchmaking connection:", err)
+			return
+		}
+		defer conn.Close()
+
+		ticketID := r.URL.Query().Get("ticket")
+		if ticketID == "" {
+			conn.WriteJSON(messages.NewConnectionErrorMessage("server", "Missing ticket in query parameters", messages.ConnectionErrorTypeTicketInvalid))
+			return
+		}
+
+		closed := waitForClose(conn)
+
+		match, err := Manager.WaitForMatch(ticketID, closed)
+		if err != nil {
+			conn.WriteJSON(messages.NewConnectionErrorMessage("server", err.Error(), messages.ConnectionErrorTypeTicketInvalid))
+			return
+		}
+
+		if err := conn.WriteJSON(messages.NewMatchFoundMessage("server", match.GameID, match.PlayerID, match.TableSize)); err != nil {
+			fmt.Println("error sending match")
+		}
+	}
+}
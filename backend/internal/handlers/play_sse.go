@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/game"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/messages"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/repository"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	sseKeepAliveInterval = 25 * time.Second
+	maxActionBodySize    = 64 * 1024
+)
+
+var errClientClosed = errors.New("client closed")
+
+// sseClient is a game.Client that streams messages as server-sent events
+type sseClient struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+	mu      sync.Mutex
+	closed  bool
+	done    chan struct{} // Closed along with the client, ends the stream
+}
+
+func (c *sseClient) Send(message messages.Message) (int, error) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return 0, err
+	}
+	return len(data), c.write("data: " + string(data) + "\n\n")
+}
+
+func (c *sseClient) write(event string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// The response writer is gone once the handler returns
+	if c.closed {
+		return errClientClosed
+	}
+	if _, err := io.WriteString(c.w, event); err != nil {
+		return err
+	}
+	c.flusher.Flush()
+	return nil
+}
+
+func (c *sseClient) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.closed {
+		c.closed = true
+		close(c.done)
+	}
+	return nil
+}
+
+// PlayEvents is the server-sent events alternative to Play for clients whose
+// network kills websockets. Actions are sent with PostAction instead.
+func PlayEvents(Manager *game.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+
+		client := &sseClient{w: w, flusher: flusher, done: make(chan struct{})}
+		defer client.Close()
+
+		queryParams := r.URL.Query()
+
+		// There is no way to say hello over a one-way stream, so the
+		// handshake happens in the query string instead
+		version, err := strconv.Atoi(queryParams.Get("protocol_version"))
+		if err != nil || !messages.IsSupportedVersion(version) {
+			client.Send(messages.NewConnectionErrorMessage("server", outdatedClientReason, messages.ConnectionErrorTypeOutdatedClient))
+			return
+		}
+
+		var requested []messages.Feature
+		for _, feature := range strings.Split(queryParams.Get("features"), ",") {
+			// Event streams are text only, so binary encodings are off the table
+			if feature != "" && messages.Feature(feature) != messages.FeatureMsgPack {
+				requested = append(requested, messages.Feature(feature))
+			}
+		}
+		opts, features := negotiate(version, requested)
+
+		game, exists := Manager.GetGame(chi.URLParam(r, "id"))
+		if !exists || game == nil {
+			client.Send(messages.NewConnectionErrorMessage("server", "Game not found", messages.ConnectionErrorTypeGameInvalid))
+			return
+		}
+
+		if _, err := client.Send(messages.NewWelcomeMessage("server", version, features)); err != nil {
+			return
+		}
+
+		playerId := queryParams.Get("player")
+		if err := game.AddConnection(playerId, client, opts); err != nil {
+			client.Send(messages.NewConnectionErrorMessage("server", "Failed to add connection: "+err.Error(), messages.ConnectionErrorTypePlayerInvalid))
+			return
+		}
+
+		defer func() {
+			client.Close()
+			game.DropConnection(playerId, client)
+		}()
+
+		ticker := time.NewTicker(sseKeepAliveInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-client.done:
+				return
+			case <-ticker.C:
+				// Comments keep proxies from closing an idle stream
+				if err := client.write(": keep-alive\n\n"); err != nil {
+					return
+				}
+			}
+		}
+	}
+}
+
+// PostAction accepts the same messages a websocket client would send. Replies
+// arrive on the player's open connection, whichever transport that is.
+func PostAction(Manager *game.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		game, exists := Manager.GetGame(chi.URLParam(r, "id"))
+		if !exists || game == nil {
+			http.Error(w, "Invalid game id", http.StatusNotFound)
+			return
+		}
+
+		playerId := r.URL.Query().Get("player")
+		if !game.HasPlayer(playerId) {
+			http.Error(w, "Invalid player id", http.StatusForbidden)
+			return
+		}
+
+		messageBytes, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxActionBodySize))
+		if err != nil {
+			http.Error(w, "Invalid request payload", http.StatusBadRequest)
+			return
+		}
+
+		err = dispatch(game, playerId, messageBytes)
+		if errors.Is(err, repository.ErrGameClosed) {
+			http.Error(w, "Game is closed", http.StatusGone)
+			return
+		}
+		if err != nil {
+			fmt.Println("Could not handle posted message:", err)
+			http.Error(w, "Invalid request payload", http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
@@ -1,11 +1,13 @@
 package handlers
 
 import (
+	"compress/flate"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/VincentZhao12/secret-hitler/backend/internal/envs"
 	"github.com/VincentZhao12/secret-hitler/backend/internal/game"
 	"github.com/VincentZhao12/secret-hitler/backend/internal/messages"
 	"github.com/gorilla/websocket"
@@ -17,19 +19,80 @@ var upgrader = websocket.Upgrader{
 	CheckOrigin:     func(r *http.Request) bool { return true }, // In production, verify origin
 }
 
-func scheduleDeleteGame(m *game.Manager, game *game.Game) {
+var compressionLevel = flate.BestSpeed
+
+// readLimit caps the size of a single frame from a client, larger frames close
+// the connection
+var readLimit int64 = 64 * 1024
+
+// ConfigureWebsocket applies the buffer, compression and read limit settings to every
+// websocket upgraded from now on
+func ConfigureWebsocket(config envs.WebsocketConfig) {
+	upgrader.ReadBufferSize = config.ReadBufferSize
+	upgrader.WriteBufferSize = config.WriteBufferSize
+	upgrader.EnableCompression = config.Compression
+	compressionLevel = config.CompressionLevel
+	readLimit = config.ReadLimit
+}
+
+func upgrade(w http.ResponseWriter, r *http.Request) (*websocket.Conn, error) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return nil, err
+	}
+	// Only has an effect if the client agreed to compression
+	conn.SetCompressionLevel(compressionLevel)
+	conn.SetReadLimit(readLimit)
+	return conn, nil
+}
+
+// waitForClose returns a channel that is closed once the client hangs up. It is
+// for sockets the client never sends anything on, reading them is still how
+// we notice they left.
+func waitForClose(conn *websocket.Conn) <-chan struct{} {
+	closed := make(chan struct{})
 	go func() {
-		time.Sleep(10 * time.Minute)
-		if g, exists := m.GetGame(game.ID); exists && g.CanBeDeleted() {
-			m.RemoveGame(game.ID)
-			fmt.Println("Deleted game", game.ID, "due to inactivity")
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
 		}
 	}()
+	return closed
+}
+
+// wsClient sends messages over a websocket in the encoding negotiated for it
+type wsClient struct {
+	conn     *websocket.Conn
+	encoding messages.Encoding
+}
+
+func (c *wsClient) Send(message messages.Message) (int, error) {
+	frameType := websocket.TextMessage
+	data, err := json.Marshal(message)
+	if c.encoding == messages.EncodingMsgPack {
+		frameType = websocket.BinaryMessage
+		data, err = messages.MarshalMsgPack(message)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return len(data), c.conn.WriteMessage(frameType, data)
+}
+
+// Close says goodbye with a close frame before dropping the connection, which
+// also ends the handler's read loop. A client that doesn't take the frame
+// within a second is dropped anyway.
+func (c *wsClient) Close() error {
+	c.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+	return c.conn.Close()
 }
 
 func Play(Manager *game.Manager) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		conn, err := upgrader.Upgrade(w, r, nil)
+		conn, err := upgrade(w, r)
 		if err != nil {
 			// TODO: Log failed connections
 			conn.WriteJSON(messages.NewConnectionErrorMessage("server", "Failed to upgrade to WebSocket: "+err.Error(), messages.ConnectionErrorTypeGameInvalid))
@@ -52,44 +115,41 @@ func Play(Manager *game.Manager) http.HandlerFunc {
 			return
 		}
 
+		opts, err := handshake(conn)
+		if err != nil {
+			fmt.Println("handshake failed:", err)
+			return
+		}
+
+		client := &wsClient{conn: conn, encoding: opts.Encoding}
 		playerId := queryParams.Get("player")
-		err = game.AddConnection(playerId, conn)
+		err = game.AddConnection(playerId, client, opts)
 		if err != nil {
-			conn.WriteJSON(messages.NewConnectionErrorMessage("server", "Failed to add connection: "+err.Error(), messages.ConnectionErrorTypePlayerInvalid))
+			client.Send(messages.NewConnectionErrorMessage("server", "Failed to add connection: "+err.Error(), messages.ConnectionErrorTypePlayerInvalid))
 			fmt.Println("no player found")
 			return
 		}
 
-		defer func() {
-			game.DropConnection(playerId)
-			scheduleDeleteGame(Manager, game)
-		}()
+		defer game.DropConnection(playerId, client)
 
 		for {
-			_, messageBytes, err := conn.ReadMessage()
+			messageType, messageBytes, err := conn.ReadMessage()
 			if err != nil {
 				fmt.Println("error reading message")
 				return
 			}
 
-			var envelope struct {
-				Base messages.BaseMessage `json:"base_message"`
-			}
-			if err := json.Unmarshal(messageBytes, &envelope); err != nil {
-				fmt.Println("Malformed web socket message: could not parse envelope")
-				continue
-			}
-
-			switch envelope.Base.GetType() {
-			case messages.MessageTypeAction:
-				var action messages.ActionMessage
-				if err := json.Unmarshal(messageBytes, &action); err != nil {
-					fmt.Println("Malformed action message")
+			// The game only understands JSON, so binary frames are converted here
+			if messageType == websocket.BinaryMessage && opts.Encoding == messages.EncodingMsgPack {
+				messageBytes, err = messages.MsgPackToJSON(messageBytes)
+				if err != nil {
+					fmt.Println("Malformed msgpack message:", err)
 					continue
 				}
-				game.ActionChan <- action
-			default:
-				fmt.Println("Unexpected message type")
+			}
+
+			if err := dispatch(game, playerId, messageBytes); err != nil {
+				fmt.Println("Could not handle web socket message:", err)
 			}
 		}
 	}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// failureLimiter blocks a client after too many failed attempts within a window
+type failureLimiter struct {
+	limit    int
+	window   time.Duration
+	mu       sync.Mutex
+	failures map[string]*failureWindow
+}
+
+type failureWindow struct {
+	count   int
+	startAt time.Time
+}
+
+func newFailureLimiter(limit int, window time.Duration) *failureLimiter {
+	return &failureLimiter{
+		limit:    limit,
+		window:   window,
+		failures: make(map[string]*failureWindow),
+	}
+}
+
+// joinLimiter throttles guessing of game passwords and invites
+var joinLimiter = newFailureLimiter(5, time.Minute)
+
+// Allow reports whether the client may make another attempt
+func (l *failureLimiter) Allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	failures, exists := l.failures[key]
+	if !exists {
+		return true
+	}
+	if time.Since(failures.startAt) > l.window {
+		delete(l.failures, key)
+		return true
+	}
+	return failures.count < l.limit
+}
+
+func (l *failureLimiter) Fail(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	// Forget windows that are over while we're here, so the map stays small
+	for other, failures := range l.failures {
+		if now.Sub(failures.startAt) > l.window {
+			delete(l.failures, other)
+		}
+	}
+
+	failures, exists := l.failures[key]
+	if !exists {
+		failures = &failureWindow{startAt: now}
+		l.failures[key] = failures
+	}
+	failures.count++
+}
+
+// ClientIP replaces the request's remote address with the one in header, which
+// only a trusted proxy may set. Requests without the header keep the peer
+// address, and an empty header name turns the middleware into a no-op.
+func ClientIP(header string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if header == "" {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if ip := net.ParseIP(strings.TrimSpace(r.Header.Get(header))); ip != nil {
+				r.RemoteAddr = ip.String()
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// clientIP is the address of the client, as set by the ClientIP middleware
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/game"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/messages"
+)
+
+const streamerPollInterval = time.Second
+
+// Spectate serves the delayed full-reveal feed for games that opted into streaming
+func Spectate(Manager *game.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrade(w, r)
+		if err != nil {
+			fmt.Println("Failed to upgrade spectator connection:", err)
+			return
+		}
+		defer conn.Close()
+
+		gameId := r.URL.Query().Get("game")
+		if gameId == "" {
+			conn.WriteJSON(messages.NewConnectionErrorMessage("server", "Missing game ID in query parameters", messages.ConnectionErrorTypeGameInvalid))
+			return
+		}
+
+		game, exists := Manager.GetGame(gameId)
+		if !exists || game == nil {
+			conn.WriteJSON(messages.NewConnectionErrorMessage("server", "Game not found", messages.ConnectionErrorTypeGameInvalid))
+			return
+		}
+
+		opts := game.StreamerOptions()
+		if !opts.Enabled {
+			conn.WriteJSON(messages.NewConnectionErrorMessage("server", "Streaming is not enabled for this game", messages.ConnectionErrorTypeGameInvalid))
+			return
+		}
+
+		closed := waitForClose(conn)
+
+		ticker := time.NewTicker(streamerPollInterval)
+		defer ticker.Stop()
+
+		lastSeq := 0
+		for {
+			select {
+			case <-closed:
+				return
+			case <-ticker.C:
+			}
+
+			if _, exists := Manager.GetGame(gameId); !exists {
+				conn.WriteJSON(messages.NewConnectionErrorMessage("server", "Game not found", messages.ConnectionErrorTypeGameInvalid))
+				return
+			}
+
+			state, seq, ok := game.DelayedState()
+			// Only resend when the delayed view has moved on
+			if !ok || seq == lastSeq {
+				continue
+			}
+			lastSeq = seq
+
+			if err := conn.WriteJSON(messages.NewStreamerStateMessage("server", state, opts.DelaySeconds, opts.DelayRounds)); err != nil {
+				fmt.Println("error sending streamer state")
+				return
+			}
+		}
+	}
+}
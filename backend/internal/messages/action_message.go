@@ -13,6 +13,7 @@ type ActionMessage struct {
 	TargetIndex int           `json:"target_index,omitempty"`
 	Vote        *bool         `json:"vote,omitempty"`
 	Text        string        `json:"text,omitempty"`
+	SeatOrder   []int         `json:"seat_order,omitempty"` // Current seat indexes in their new order
 }
 
 func NewActionMessage(senderID string, action models.Action, targetIndex int, vote bool, text string) *ActionMessage {
@@ -28,29 +29,72 @@ func NewActionMessage(senderID string, action models.Action, targetIndex int, vo
 	}
 }
 
-type ActionErrorReason string
+// ActionErrorCode is a stable, machine-readable reason an action was refused.
+// Clients should switch on the code and only fall back to Reason for codes
+// they don't know yet.
+type ActionErrorCode string
 
 const (
-	NotAllowed    ActionErrorReason = "Action not allowed"
-	InvalidTarget ActionErrorReason = "Targeted player is cannot be targeted for this action"
-	CouldNotStart ActionErrorReason = "Could not start game"
+	ErrorCodeUnknownAction      ActionErrorCode = "unknown_action"
+	ErrorCodeUnknownPlayer      ActionErrorCode = "unknown_player"
+	ErrorCodeNotHost            ActionErrorCode = "not_host"
+	ErrorCodeNotYourTurn        ActionErrorCode = "not_your_turn"
+	ErrorCodeWrongPhase         ActionErrorCode = "wrong_phase"
+	ErrorCodeInvalidTarget      ActionErrorCode = "invalid_target"
+	ErrorCodeTargetExecuted     ActionErrorCode = "target_executed"
+	ErrorCodePlayerExecuted     ActionErrorCode = "player_executed"
+	ErrorCodeTermLimited        ActionErrorCode = "term_limited"
+	ErrorCodeAlreadyVoted       ActionErrorCode = "already_voted"
+	ErrorCodeMissingVote        ActionErrorCode = "missing_vote"
+	ErrorCodeInvalidCard        ActionErrorCode = "invalid_card"
+	ErrorCodeInvalidChat        ActionErrorCode = "invalid_chat"
+	ErrorCodeInvalidPlayerCount ActionErrorCode = "invalid_player_count"
+	ErrorCodeGracePeriod        ActionErrorCode = "grace_period"
+	ErrorCodeInvalidSeatOrder   ActionErrorCode = "invalid_seat_order"
+	ErrorCodeNotReady           ActionErrorCode = "players_not_ready"
 )
 
-func InvalidAction(action models.Action) ActionErrorReason {
-	return ActionErrorReason("Invalid action: " + action)
+var defaultReasons = map[ActionErrorCode]string{
+	ErrorCodeUnknownAction:      "Unknown action",
+	ErrorCodeUnknownPlayer:      "You are not a player in this game",
+	ErrorCodeNotHost:            "Only the host can do that",
+	ErrorCodeNotYourTurn:        "It is not your turn to do that",
+	ErrorCodeWrongPhase:         "That can't be done in the current phase",
+	ErrorCodeInvalidTarget:      "That player can't be targeted for this action",
+	ErrorCodeTargetExecuted:     "That player has been executed",
+	ErrorCodePlayerExecuted:     "Executed players can't act",
+	ErrorCodeTermLimited:        "That player is term limited",
+	ErrorCodeAlreadyVoted:       "You have already voted",
+	ErrorCodeMissingVote:        "No vote was given",
+	ErrorCodeInvalidCard:        "That card can't be chosen",
+	ErrorCodeInvalidChat:        "Chat messages can't be empty or too long",
+	ErrorCodeInvalidPlayerCount: "The game needs between 5 and 10 players",
+	ErrorCodeGracePeriod:        "Missing players still have time to reconnect",
+	ErrorCodeInvalidSeatOrder:   "The new seat order must list every seat exactly once",
+	ErrorCodeNotReady:           "Everyone has to confirm they're ready first",
 }
 
+// ActionErrorParams carries the details of an error, e.g. the expected phase
+// for ErrorCodeWrongPhase or the target for ErrorCodeTermLimited
+type ActionErrorParams map[string]any
+
 type ActionErrorMessage struct {
 	BaseMessage `json:"base_message" tstype:"BaseMessage"`
-	Reason      ActionErrorReason `json:"reason"`
+	Action      models.Action     `json:"action" tstype:"Action"`
+	Code        ActionErrorCode   `json:"code"`
+	Params      ActionErrorParams `json:"params,omitempty" tstype:"Record<string, any>"`
+	Reason      string            `json:"reason"`
 }
 
-func NewActionErrorMessage(senderID string, reason ActionErrorReason) *ActionErrorMessage {
+func NewActionErrorMessage(senderID string, action models.Action, code ActionErrorCode, params ActionErrorParams) *ActionErrorMessage {
 	return &ActionErrorMessage{
 		BaseMessage: BaseMessage{
 			Type:     MessageTypeActionError,
 			SenderID: senderID,
 		},
-		Reason: reason,
+		Action: action,
+		Code:   code,
+		Params: params,
+		Reason: defaultReasons[code],
 	}
 }
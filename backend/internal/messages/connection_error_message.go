@@ -11,6 +11,14 @@ const (
 	ConnectionErrorTypeGameInvalid
 	ConnectionErrorTypePlayerInvalid
 	ConnectionErrorTypeServerError
+	ConnectionErrorTypeOutdatedClient
+	// The server is going down for a restart, the game survives it and
+	// clients should keep trying to reconnect
+	ConnectionErrorTypeServerRestarting
+	// The host removed the player from the lobby, their ID is no longer valid
+	ConnectionErrorTypeKicked
+	// The matchmaking ticket is unknown, expired or the queue shut down
+	ConnectionErrorTypeTicketInvalid
 )
 
 type ConnectionErrorMessage struct {
@@ -1,6 +1,6 @@
 package messages
 
-import "github.com/VincentZhao12/secret-hitler/backend/internal/models"
+import "github.com/VincentZhao12/secret-hitler/backend/internal/views"
 
 const (
 	MessageTypeGameState MessageType = "game_state"
@@ -8,10 +8,11 @@ const (
 
 type GameStateMessage struct {
 	BaseMessage `json:"base_message" tstype:"BaseMessage"`
-	GameState   models.GameState `json:"game_state" tstype:"GameState"`
+	GameState   views.PlayerView `json:"game_state" tstype:"PlayerView"`
+	Seq         uint64           `json:"seq,omitempty"`
 }
 
-func NewGameStateMessage(senderID string, gameState models.GameState) *GameStateMessage {
+func NewGameStateMessage(senderID string, gameState views.PlayerView) *GameStateMessage {
 	return &GameStateMessage{
 		BaseMessage: BaseMessage{
 			Type:     MessageTypeGameState,
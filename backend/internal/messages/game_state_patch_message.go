@@ -0,0 +1,24 @@
+package messages
+
+const (
+	MessageTypeGameStatePatch MessageType = "game_state_patch"
+)
+
+// GameStatePatchMessage carries the changes since the message with sequence
+// number Seq-1. Clients that notice a gap should send a ResyncMessage.
+type GameStatePatchMessage struct {
+	BaseMessage `json:"base_message" tstype:"BaseMessage"`
+	Seq         uint64           `json:"seq"`
+	Patch       []PatchOperation `json:"patch"`
+}
+
+func NewGameStatePatchMessage(senderID string, seq uint64, patch []PatchOperation) *GameStatePatchMessage {
+	return &GameStatePatchMessage{
+		BaseMessage: BaseMessage{
+			Type:     MessageTypeGameStatePatch,
+			SenderID: senderID,
+		},
+		Seq:   seq,
+		Patch: patch,
+	}
+}
@@ -0,0 +1,42 @@
+package messages
+
+const (
+	MessageTypeHello   MessageType = "hello"
+	MessageTypeWelcome MessageType = "welcome"
+)
+
+// HelloMessage must be the first message a client sends after connecting
+type HelloMessage struct {
+	BaseMessage     `json:"base_message" tstype:"BaseMessage"`
+	ProtocolVersion int       `json:"protocol_version"`
+	Features        []Feature `json:"features"`
+}
+
+func NewHelloMessage(senderID string, protocolVersion int, features []Feature) *HelloMessage {
+	return &HelloMessage{
+		BaseMessage: BaseMessage{
+			Type:     MessageTypeHello,
+			SenderID: senderID,
+		},
+		ProtocolVersion: protocolVersion,
+		Features:        features,
+	}
+}
+
+// WelcomeMessage answers a hello with the version and features in use for the connection
+type WelcomeMessage struct {
+	BaseMessage     `json:"base_message" tstype:"BaseMessage"`
+	ProtocolVersion int       `json:"protocol_version"`
+	Features        []Feature `json:"features"`
+}
+
+func NewWelcomeMessage(senderID string, protocolVersion int, features []Feature) *WelcomeMessage {
+	return &WelcomeMessage{
+		BaseMessage: BaseMessage{
+			Type:     MessageTypeWelcome,
+			SenderID: senderID,
+		},
+		ProtocolVersion: protocolVersion,
+		Features:        features,
+	}
+}
@@ -0,0 +1,22 @@
+package messages
+
+import "github.com/VincentZhao12/secret-hitler/backend/internal/views"
+
+const (
+	MessageTypeLobbyList MessageType = "lobby_list"
+)
+
+type LobbyListMessage struct {
+	BaseMessage `json:"base_message" tstype:"BaseMessage"`
+	Games       []views.LobbyListing `json:"games" tstype:"LobbyListing[]"`
+}
+
+func NewLobbyListMessage(senderID string, games []views.LobbyListing) *LobbyListMessage {
+	return &LobbyListMessage{
+		BaseMessage: BaseMessage{
+			Type:     MessageTypeLobbyList,
+			SenderID: senderID,
+		},
+		Games: games,
+	}
+}
@@ -0,0 +1,26 @@
+package messages
+
+const (
+	MessageTypeMatchFound MessageType = "match_found"
+)
+
+// MatchFoundMessage tells a queued player which game they were seated in. They
+// join it like any other game with the IDs it carries.
+type MatchFoundMessage struct {
+	BaseMessage `json:"base_message" tstype:"BaseMessage"`
+	GameID      string `json:"game_id"`
+	PlayerID    string `json:"player_id"`
+	TableSize   int    `json:"table_size"`
+}
+
+func NewMatchFoundMessage(senderID string, gameID string, playerID string, tableSize int) *MatchFoundMessage {
+	return &MatchFoundMessage{
+		BaseMessage: BaseMessage{
+			Type:     MessageTypeMatchFound,
+			SenderID: senderID,
+		},
+		GameID:    gameID,
+		PlayerID:  playerID,
+		TableSize: tableSize,
+	}
+}
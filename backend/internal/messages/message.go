@@ -5,11 +5,16 @@ type MessageType string
 type Message interface {
 	GetType() MessageType
 	GetSenderID() string
+	GetRequestID() string
+	SetRequestID(requestID string)
 }
 
 type BaseMessage struct {
 	Type     MessageType `json:"type"`
 	SenderID string      `json:"sender_id"`
+	// RequestID is optionally set by clients on actions and echoed back on
+	// every reply to that action
+	RequestID string `json:"request_id,omitempty"`
 }
 
 func (m *BaseMessage) GetType() MessageType {
@@ -19,3 +24,11 @@ func (m *BaseMessage) GetType() MessageType {
 func (m *BaseMessage) GetSenderID() string {
 	return m.SenderID
 }
+
+func (m *BaseMessage) GetRequestID() string {
+	return m.RequestID
+}
+
+func (m *BaseMessage) SetRequestID(requestID string) {
+	m.RequestID = requestID
+}
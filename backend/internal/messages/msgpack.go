@@ -0,0 +1,320 @@
+package messages
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// MessagePack support is built on top of the JSON encoding: messages are
+// marshalled to JSON first and the resulting document is re-encoded. That way
+// field names, omitempty and custom marshallers behave exactly as they do for
+// JSON clients, and the two encodings can't drift apart.
+
+var (
+	errMsgPackTruncated   = errors.New("msgpack: unexpected end of data")
+	errMsgPackUnsupported = errors.New("msgpack: unsupported type")
+	errMsgPackTooDeep     = errors.New("msgpack: nesting too deep")
+)
+
+// maxMsgPackDepth bounds how deeply arrays and maps may nest in incoming
+// documents. Nothing the client sends comes close, and without a bound a
+// crafted frame can recurse until the stack runs out.
+const maxMsgPackDepth = 32
+
+// MarshalMsgPack encodes v as MessagePack, using the same shape as its JSON encoding
+func MarshalMsgPack(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := writeMsgPack(&buf, doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// MsgPackToJSON converts a MessagePack document into the equivalent JSON
+func MsgPackToJSON(data []byte) ([]byte, error) {
+	reader := &msgPackReader{data: data}
+	doc, err := reader.read()
+	if err != nil {
+		return nil, err
+	}
+	if reader.pos != len(data) {
+		return nil, fmt.Errorf("msgpack: %d trailing bytes", len(data)-reader.pos)
+	}
+	return json.Marshal(doc)
+}
+
+func writeMsgPack(buf *bytes.Buffer, v any) error {
+	switch value := v.(type) {
+	case nil:
+		buf.WriteByte(0xc0)
+	case bool:
+		if value {
+			buf.WriteByte(0xc3)
+		} else {
+			buf.WriteByte(0xc2)
+		}
+	case json.Number:
+		if i, err := value.Int64(); err == nil {
+			writeMsgPackInt(buf, i)
+			return nil
+		}
+		f, err := value.Float64()
+		if err != nil {
+			return err
+		}
+		buf.WriteByte(0xcb)
+		binary.Write(buf, binary.BigEndian, math.Float64bits(f))
+	case string:
+		writeMsgPackHeader(buf, len(value), 0xa0, 31, 0xd9, 0xda, 0xdb)
+		buf.WriteString(value)
+	case []any:
+		writeMsgPackHeader(buf, len(value), 0x90, 15, 0, 0xdc, 0xdd)
+		for _, item := range value {
+			if err := writeMsgPack(buf, item); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		writeMsgPackHeader(buf, len(value), 0x80, 15, 0, 0xde, 0xdf)
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			writeMsgPack(buf, key)
+			if err := writeMsgPack(buf, value[key]); err != nil {
+				return err
+			}
+		}
+	default:
+		return errMsgPackUnsupported
+	}
+	return nil
+}
+
+func writeMsgPackInt(buf *bytes.Buffer, i int64) {
+	switch {
+	case i >= 0 && i <= 127:
+		buf.WriteByte(byte(i))
+	case i < 0 && i >= -32:
+		buf.WriteByte(byte(0xe0 | (i + 32)))
+	case i >= math.MinInt8 && i <= math.MaxInt8:
+		buf.WriteByte(0xd0)
+		buf.WriteByte(byte(int8(i)))
+	case i >= math.MinInt16 && i <= math.MaxInt16:
+		buf.WriteByte(0xd1)
+		binary.Write(buf, binary.BigEndian, int16(i))
+	case i >= math.MinInt32 && i <= math.MaxInt32:
+		buf.WriteByte(0xd2)
+		binary.Write(buf, binary.BigEndian, int32(i))
+	default:
+		buf.WriteByte(0xd3)
+		binary.Write(buf, binary.BigEndian, i)
+	}
+}
+
+// writeMsgPackHeader writes the length prefix of a string, array or map. A
+// zero code means the format has no 8 bit length variant.
+func writeMsgPackHeader(buf *bytes.Buffer, length int, fixCode byte, fixMax int, code8 byte, code16 byte, code32 byte) {
+	switch {
+	case length <= fixMax:
+		buf.WriteByte(fixCode | byte(length))
+	case code8 != 0 && length <= math.MaxUint8:
+		buf.WriteByte(code8)
+		buf.WriteByte(byte(length))
+	case length <= math.MaxUint16:
+		buf.WriteByte(code16)
+		binary.Write(buf, binary.BigEndian, uint16(length))
+	default:
+		buf.WriteByte(code32)
+		binary.Write(buf, binary.BigEndian, uint32(length))
+	}
+}
+
+type msgPackReader struct {
+	data  []byte
+	pos   int
+	depth int
+}
+
+func (r *msgPackReader) next(n int) ([]byte, error) {
+	if n < 0 || r.pos+n > len(r.data) {
+		return nil, errMsgPackTruncated
+	}
+	b := r.data[r.pos : r.pos+n]
+	r.pos += n
+	return b, nil
+}
+
+func (r *msgPackReader) uint(size int) (uint64, error) {
+	b, err := r.next(size)
+	if err != nil {
+		return 0, err
+	}
+	switch size {
+	case 1:
+		return uint64(b[0]), nil
+	case 2:
+		return uint64(binary.BigEndian.Uint16(b)), nil
+	case 4:
+		return uint64(binary.BigEndian.Uint32(b)), nil
+	default:
+		return binary.BigEndian.Uint64(b), nil
+	}
+}
+
+func (r *msgPackReader) read() (any, error) {
+	head, err := r.next(1)
+	if err != nil {
+		return nil, err
+	}
+	code := head[0]
+
+	switch {
+	case code <= 0x7f:
+		return int64(code), nil
+	case code >= 0xe0:
+		return int64(int8(code)), nil
+	case code&0xe0 == 0xa0:
+		return r.readString(int(code & 0x1f))
+	case code&0xf0 == 0x90:
+		return r.readArray(int(code & 0x0f))
+	case code&0xf0 == 0x80:
+		return r.readMap(int(code & 0x0f))
+	}
+
+	switch code {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		return r.uint(1 << (code - 0xcc))
+	case 0xd0:
+		u, err := r.uint(1)
+		return int64(int8(u)), err
+	case 0xd1:
+		u, err := r.uint(2)
+		return int64(int16(u)), err
+	case 0xd2:
+		u, err := r.uint(4)
+		return int64(int32(u)), err
+	case 0xd3:
+		u, err := r.uint(8)
+		return int64(u), err
+	case 0xca:
+		u, err := r.uint(4)
+		return float64(math.Float32frombits(uint32(u))), err
+	case 0xcb:
+		u, err := r.uint(8)
+		return math.Float64frombits(u), err
+	case 0xd9, 0xda, 0xdb:
+		length, err := r.uint(1 << (code - 0xd9))
+		if err != nil {
+			return nil, err
+		}
+		return r.readString(int(length))
+	case 0xc4, 0xc5, 0xc6:
+		// Binary is read as a string, JSON has nothing closer
+		length, err := r.uint(1 << (code - 0xc4))
+		if err != nil {
+			return nil, err
+		}
+		return r.readString(int(length))
+	case 0xdc, 0xdd:
+		length, err := r.uint(2 << (code - 0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return r.readArray(int(length))
+	case 0xde, 0xdf:
+		length, err := r.uint(2 << (code - 0xde))
+		if err != nil {
+			return nil, err
+		}
+		return r.readMap(int(length))
+	}
+
+	return nil, fmt.Errorf("msgpack: unsupported type code 0x%x", code)
+}
+
+func (r *msgPackReader) readString(length int) (string, error) {
+	b, err := r.next(length)
+	return string(b), err
+}
+
+// enter tracks one more level of nesting, the returned func leaves it again
+func (r *msgPackReader) enter() (func(), error) {
+	if r.depth >= maxMsgPackDepth {
+		return nil, errMsgPackTooDeep
+	}
+	r.depth++
+	return func() { r.depth-- }, nil
+}
+
+func (r *msgPackReader) readArray(length int) ([]any, error) {
+	// Every element takes at least a byte, which bounds bogus lengths
+	if length > len(r.data)-r.pos {
+		return nil, errMsgPackTruncated
+	}
+	leave, err := r.enter()
+	if err != nil {
+		return nil, err
+	}
+	defer leave()
+
+	array := make([]any, length)
+	for i := range array {
+		item, err := r.read()
+		if err != nil {
+			return nil, err
+		}
+		array[i] = item
+	}
+	return array, nil
+}
+
+func (r *msgPackReader) readMap(length int) (map[string]any, error) {
+	if length > len(r.data)-r.pos {
+		return nil, errMsgPackTruncated
+	}
+	leave, err := r.enter()
+	if err != nil {
+		return nil, err
+	}
+	defer leave()
+
+	m := make(map[string]any, length)
+	for i := 0; i < length; i++ {
+		key, err := r.read()
+		if err != nil {
+			return nil, err
+		}
+		value, err := r.read()
+		if err != nil {
+			return nil, err
+		}
+		m[fmt.Sprint(key)] = value
+	}
+	return m, nil
+}
@@ -0,0 +1,242 @@
+package messages
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/models"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/views"
+)
+
+// roundTrip encodes the message as MessagePack, decodes it back into a fresh
+// message of the same type and checks it marshals to the same JSON as the
+// original did
+func roundTrip[T Message](t *testing.T, message T, decoded T) {
+	t.Helper()
+
+	want, err := json.Marshal(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	packed, err := MarshalMsgPack(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unpacked, err := MsgPackToJSON(packed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Map keys come back sorted, so the documents are compared by value
+	var wantDoc, gotDoc any
+	if err := json.Unmarshal(want, &wantDoc); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(unpacked, &gotDoc); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(wantDoc, gotDoc) {
+		t.Errorf("document changed in MessagePack\nwant %s\ngot  %s", want, unpacked)
+	}
+
+	if err := json.Unmarshal(unpacked, decoded); err != nil {
+		t.Fatal(err)
+	}
+	got, err := json.Marshal(decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(want, got) {
+		t.Errorf("message changed in MessagePack\nwant %s\ngot  %s", want, got)
+	}
+}
+
+func TestActionMessageMsgPack(t *testing.T) {
+	yes := true
+	no := false
+
+	tests := []struct {
+		name    string
+		message *ActionMessage
+	}{
+		{"no vote", &ActionMessage{
+			BaseMessage: BaseMessage{Type: MessageTypeAction, SenderID: "player"},
+			Action:      models.ActionNominate,
+			TargetIndex: 3,
+		}},
+		{"vote ja", &ActionMessage{
+			BaseMessage: BaseMessage{Type: MessageTypeAction, SenderID: "player", RequestID: "req-1"},
+			Action:      models.ActionVote,
+			Vote:        &yes,
+		}},
+		{"vote nein", &ActionMessage{
+			BaseMessage: BaseMessage{Type: MessageTypeAction, SenderID: "player", RequestID: "req-2"},
+			Action:      models.ActionVote,
+			Vote:        &no,
+		}},
+		{"seat order", &ActionMessage{
+			BaseMessage: BaseMessage{Type: MessageTypeAction, SenderID: "host"},
+			Action:      models.ActionReorderSeats,
+			SeatOrder:   []int{4, 0, 3, 1, 2},
+		}},
+		{"empty request ID", &ActionMessage{
+			BaseMessage: BaseMessage{Type: MessageTypeAction, SenderID: "player", RequestID: ""},
+			Action:      models.ActionChatSend,
+			Text:        "héllo, 世界",
+		}},
+		{"long request ID", &ActionMessage{
+			BaseMessage: BaseMessage{Type: MessageTypeAction, SenderID: "player", RequestID: string(bytes.Repeat([]byte("r"), 300))},
+			Action:      models.ActionLegislate,
+			TargetIndex: -1,
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			roundTrip(t, test.message, &ActionMessage{})
+		})
+	}
+}
+
+func TestActionMessageMsgPackRequestID(t *testing.T) {
+	// An empty request ID is left out just like a missing one, so a client
+	// can't tell them apart in either encoding
+	withEmpty, err := MarshalMsgPack(&ActionMessage{BaseMessage: BaseMessage{Type: MessageTypeAction, RequestID: ""}, Action: models.ActionReady})
+	if err != nil {
+		t.Fatal(err)
+	}
+	without, err := MarshalMsgPack(&ActionMessage{BaseMessage: BaseMessage{Type: MessageTypeAction}, Action: models.ActionReady})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(withEmpty, without) {
+		t.Errorf("an empty request ID encodes differently from a missing one")
+	}
+
+	decoded := &ActionMessage{}
+	data, err := MsgPackToJSON(withEmpty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.RequestID != "" || decoded.Vote != nil {
+		t.Errorf("decoded %+v, want no request ID or vote", decoded)
+	}
+}
+
+func TestActionErrorMessageMsgPack(t *testing.T) {
+	tests := []struct {
+		name    string
+		message *ActionErrorMessage
+	}{
+		{"no params", NewActionErrorMessage("server", models.ActionVote, ErrorCodeAlreadyVoted, nil)},
+		{"params", NewActionErrorMessage("server", models.ActionStartGame, ErrorCodeNotReady, ActionErrorParams{
+			"not_ready": []int{1, 4},
+			"phase":     models.ReadyCheck,
+			"count":     70000,
+			"ratio":     0.5,
+			"negative":  -200,
+		})},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.message.SetRequestID("req-7")
+			roundTrip(t, test.message, &ActionErrorMessage{})
+		})
+	}
+}
+
+func TestGameStateMessageMsgPack(t *testing.T) {
+	state := models.NewGameState()
+	for i := range 7 {
+		if _, err := state.AddPlayer(fmt.Sprintf("id%d", i), fmt.Sprintf("player%d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	state.HostID = "id0"
+
+	lobby := NewGameStateMessage("server", views.ForPlayer(&state, "id2"))
+
+	if err := state.StartGame(); err != nil {
+		t.Fatal(err)
+	}
+	state.Phase = models.Election
+	state.Votes = make([]models.VoteResult, len(state.Players))
+	state.Votes[3] = models.VoteJa
+	state.ChatHistory = append(state.ChatHistory, models.ChatEntry{SenderID: "id1", SenderName: "player1", Text: "ja?"})
+	election := NewGameStateMessage("server", views.ForPlayer(&state, "id2"))
+	election.SetRequestID("req-9")
+	election.Seq = 1 << 40
+
+	tests := []struct {
+		name    string
+		message *GameStateMessage
+	}{
+		{"lobby", lobby},
+		{"election", election},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			roundTrip(t, test.message, &GameStateMessage{})
+		})
+	}
+}
+
+func TestMsgPackToJSONNesting(t *testing.T) {
+	nested := func(depth int, open byte) []byte {
+		data := bytes.Repeat([]byte{open}, depth)
+		if open == 0x81 {
+			// Every map level needs a key before its value
+			data = bytes.Repeat([]byte{0x81, 0xa1, 'k'}, depth)
+		}
+		return append(data, 0xc0)
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{"arrays at the limit", nested(maxMsgPackDepth, 0x91), nil},
+		{"maps at the limit", nested(maxMsgPackDepth, 0x81), nil},
+		{"arrays past the limit", nested(maxMsgPackDepth+1, 0x91), errMsgPackTooDeep},
+		{"maps past the limit", nested(maxMsgPackDepth+1, 0x81), errMsgPackTooDeep},
+		{"huge array nesting", nested(1<<20, 0x91), errMsgPackTooDeep},
+		{"array16 nesting", bytes.Repeat([]byte{0xdc, 0x00, 0x01}, 1<<16), errMsgPackTooDeep},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := MsgPackToJSON(test.data)
+			if err != test.wantErr {
+				t.Errorf("got error %v, want %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestMsgPackToJSONSiblingsDontCountAsNesting(t *testing.T) {
+	// A wide, shallow document must decode however many containers it holds
+	data := []byte{0xdc, 0x01, 0x00}
+	data = append(data, bytes.Repeat([]byte{0x91, 0x01}, 256)...)
+
+	out, err := MsgPackToJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc [][]int
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if len(doc) != 256 {
+		t.Errorf("got %d items, want 256", len(doc))
+	}
+}
@@ -0,0 +1,139 @@
+package messages
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type PatchOp string
+
+const (
+	PatchOpAdd     PatchOp = "add"
+	PatchOpRemove  PatchOp = "remove"
+	PatchOpReplace PatchOp = "replace"
+)
+
+// PatchOperation is a single RFC 6902 JSON Patch operation
+type PatchOperation struct {
+	Op    PatchOp         `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value,omitempty" tstype:"any"`
+}
+
+// Diff returns the JSON Patch turning one decoded JSON document into another.
+// Both documents must be made of the types produced by json.Unmarshal into an any.
+func Diff(from any, to any) []PatchOperation {
+	ops := []PatchOperation{}
+	diff("", from, to, &ops)
+	return ops
+}
+
+func diff(path string, from any, to any, ops *[]PatchOperation) {
+	switch f := from.(type) {
+	case map[string]any:
+		t, ok := to.(map[string]any)
+		if !ok {
+			addOp(ops, PatchOpReplace, path, to)
+			return
+		}
+
+		for _, key := range sortedKeys(f) {
+			childPath := path + "/" + escapePointer(key)
+			if value, exists := t[key]; exists {
+				diff(childPath, f[key], value, ops)
+			} else {
+				addOp(ops, PatchOpRemove, childPath, nil)
+			}
+		}
+		for _, key := range sortedKeys(t) {
+			if _, exists := f[key]; !exists {
+				addOp(ops, PatchOpAdd, path+"/"+escapePointer(key), t[key])
+			}
+		}
+
+	case []any:
+		t, ok := to.([]any)
+		if !ok {
+			addOp(ops, PatchOpReplace, path, to)
+			return
+		}
+
+		// Chat history is trimmed from the front once it is full, which would
+		// otherwise turn into a replace of every single entry
+		dropped := droppedPrefix(f, t)
+		for i := 0; i < dropped; i++ {
+			addOp(ops, PatchOpRemove, path+"/0", nil)
+		}
+		f = f[dropped:]
+
+		common := min(len(f), len(t))
+		for i := 0; i < common; i++ {
+			diff(path+"/"+strconv.Itoa(i), f[i], t[i], ops)
+		}
+		for i := len(f) - 1; i >= len(t); i-- {
+			addOp(ops, PatchOpRemove, path+"/"+strconv.Itoa(i), nil)
+		}
+		for i := len(f); i < len(t); i++ {
+			addOp(ops, PatchOpAdd, path+"/-", t[i])
+		}
+
+	default:
+		if !reflect.DeepEqual(from, to) {
+			addOp(ops, PatchOpReplace, path, to)
+		}
+	}
+}
+
+// droppedPrefix returns how many elements were removed from the front of from
+// to produce the start of to, or 0 if to doesn't look like a shifted from
+func droppedPrefix(from []any, to []any) int {
+	if len(from) == 0 || len(to) == 0 || reflect.DeepEqual(from[0], to[0]) {
+		return 0
+	}
+
+	for k := 1; k < len(from); k++ {
+		remaining := len(from) - k
+		if remaining > len(to) || !reflect.DeepEqual(from[k], to[0]) {
+			continue
+		}
+		if reflect.DeepEqual(from[k:], to[:remaining]) {
+			return k
+		}
+	}
+
+	return 0
+}
+
+func addOp(ops *[]PatchOperation, op PatchOp, path string, value any) {
+	operation := PatchOperation{
+		Op:   op,
+		Path: path,
+	}
+
+	if op != PatchOpRemove {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			raw = json.RawMessage("null")
+		}
+		operation.Value = raw
+	}
+
+	*ops = append(*ops, operation)
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func escapePointer(token string) string {
+	token = strings.ReplaceAll(token, "~", "~0")
+	return strings.ReplaceAll(token, "/", "~1")
+}
@@ -0,0 +1,184 @@
+package messages
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// applyPatch applies a JSON Patch the way a client would, failing on anything
+// a strict implementation would reject
+func applyPatch(doc any, patch []PatchOperation) (any, error) {
+	for _, op := range patch {
+		var value any
+		if op.Op != PatchOpRemove {
+			if err := json.Unmarshal(op.Value, &value); err != nil {
+				return nil, fmt.Errorf("%s %s: %w", op.Op, op.Path, err)
+			}
+		}
+
+		var err error
+		doc, err = applyOp(doc, pointerTokens(op.Path), op.Op, value)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", op.Op, op.Path, err)
+		}
+	}
+	return doc, nil
+}
+
+func pointerTokens(path string) []string {
+	if path == "" {
+		return nil
+	}
+	tokens := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens
+}
+
+func applyOp(doc any, tokens []string, op PatchOp, value any) (any, error) {
+	if len(tokens) == 0 {
+		if op != PatchOpReplace {
+			return nil, fmt.Errorf("can't %s the whole document", op)
+		}
+		return value, nil
+	}
+
+	token, rest := tokens[0], tokens[1:]
+	switch container := doc.(type) {
+	case map[string]any:
+		child, exists := container[token]
+		if len(rest) > 0 {
+			if !exists {
+				return nil, fmt.Errorf("missing key %q", token)
+			}
+			updated, err := applyOp(child, rest, op, value)
+			container[token] = updated
+			return container, err
+		}
+		switch op {
+		case PatchOpAdd:
+			container[token] = value
+		case PatchOpReplace, PatchOpRemove:
+			if !exists {
+				return nil, fmt.Errorf("missing key %q", token)
+			}
+			if op == PatchOpRemove {
+				delete(container, token)
+			} else {
+				container[token] = value
+			}
+		}
+		return container, nil
+
+	case []any:
+		if token == "-" {
+			if op != PatchOpAdd || len(rest) > 0 {
+				return nil, fmt.Errorf("- only appends")
+			}
+			return append(container, value), nil
+		}
+		index, err := strconv.Atoi(token)
+		if err != nil || index < 0 || index > len(container) || (index == len(container) && op != PatchOpAdd) {
+			return nil, fmt.Errorf("bad index %q for length %d", token, len(container))
+		}
+		if len(rest) > 0 {
+			updated, err := applyOp(container[index], rest, op, value)
+			container[index] = updated
+			return container, err
+		}
+		switch op {
+		case PatchOpAdd:
+			container = append(container[:index], append([]any{value}, container[index:]...)...)
+		case PatchOpRemove:
+			container = append(container[:index], container[index+1:]...)
+		case PatchOpReplace:
+			container[index] = value
+		}
+		return container, nil
+	}
+
+	return nil, fmt.Errorf("can't index %T with %q", doc, token)
+}
+
+func decodeJSON(t *testing.T, data string) any {
+	t.Helper()
+	var doc any
+	if err := json.Unmarshal([]byte(data), &doc); err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		wantOps int // -1 to skip the count
+	}{
+		{"unchanged", `{"a": 1, "b": [1, 2], "c": {"d": null}}`, `{"a": 1, "b": [1, 2], "c": {"d": null}}`, 0},
+		{"replace field", `{"a": 1}`, `{"a": 2}`, 1},
+		{"add field", `{"a": 1}`, `{"a": 1, "b": "x"}`, 1},
+		{"remove field", `{"a": 1, "b": "x"}`, `{"a": 1}`, 1},
+		{"field becomes null", `{"a": {"b": 1}}`, `{"a": null}`, 1},
+		{"field stops being null", `{"a": null}`, `{"a": {"b": 1}}`, 1},
+		{"object becomes array", `{"a": {"b": 1}}`, `{"a": [1]}`, 1},
+		{"nested objects", `{"a": {"b": {"c": 1, "d": 2}}}`, `{"a": {"b": {"c": 1, "d": 3, "e": 4}}}`, 2},
+		{"escaped keys", `{"a/b": 1, "c~d": 2}`, `{"a/b": 2, "c~d": 3}`, 2},
+		{"array grows", `{"a": [1, 2]}`, `{"a": [1, 2, 3, 4]}`, 2},
+		{"array grows from empty", `{"a": []}`, `{"a": [{"b": 1}]}`, 1},
+		{"array shrinks", `{"a": [1, 2, 3, 4]}`, `{"a": [1, 2]}`, 2},
+		{"array shrinks to empty", `{"a": [1, 2]}`, `{"a": []}`, 2},
+		{"array element changes", `{"a": [{"b": 1}, {"b": 2}]}`, `{"a": [{"b": 1}, {"b": 3}]}`, 1},
+		{"array becomes null", `{"a": [1, 2]}`, `{"a": null}`, 1},
+		{"prefix dropped", `{"a": [1, 2, 3, 4]}`, `{"a": [3, 4]}`, 2},
+		{"prefix dropped and appended", `{"a": [1, 2, 3, 4]}`, `{"a": [2, 3, 4, 5]}`, 2},
+		{"prefix of objects dropped", `{"a": [{"t": "x"}, {"t": "y"}, {"t": "z"}]}`, `{"a": [{"t": "y"}, {"t": "z"}, {"t": "w"}]}`, 2},
+		{"prefix dropped from full history", `{"a": [1, 2, 3, 4, 5]}`, `{"a": [4, 5, 6, 7, 8]}`, 6},
+		{"not a shifted array", `{"a": [1, 2, 3]}`, `{"a": [3, 1, 2]}`, -1},
+		{"repeated values", `{"a": [1, 1, 2]}`, `{"a": [1, 2, 2]}`, -1},
+		{"nested arrays", `{"a": [[1], [2, 3]]}`, `{"a": [[1, 4], [3]]}`, -1},
+		{"root replaced", `[1, 2]`, `{"a": 1}`, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			from := decodeJSON(t, test.from)
+			to := decodeJSON(t, test.to)
+
+			patch := Diff(from, to)
+			if test.wantOps >= 0 && len(patch) != test.wantOps {
+				t.Errorf("got %d operations, want %d: %+v", len(patch), test.wantOps, patch)
+			}
+
+			// Diff must not touch its inputs, the patch is applied to a fresh copy
+			got, err := applyPatch(decodeJSON(t, test.from), patch)
+			if err != nil {
+				t.Fatalf("patch doesn't apply: %v\npatch %+v", err, patch)
+			}
+			if !reflect.DeepEqual(got, to) {
+				gotJSON, _ := json.Marshal(got)
+				t.Errorf("patched document differs\nwant %s\ngot  %s\npatch %+v", test.to, gotJSON, patch)
+			}
+			if !reflect.DeepEqual(from, decodeJSON(t, test.from)) {
+				t.Errorf("Diff changed its input")
+			}
+		})
+	}
+}
+
+func TestDiffIsEmptyJSONArray(t *testing.T) {
+	// Clients loop over the patch, so no changes must still encode as a list
+	encoded, err := json.Marshal(Diff(decodeJSON(t, `{"a": 1}`), decodeJSON(t, `{"a": 1}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(encoded) != "[]" {
+		t.Errorf("got %s, want []", encoded)
+	}
+}
@@ -0,0 +1,48 @@
+package messages
+
+import "slices"
+
+// ProtocolVersion is bumped whenever a change to the messages would break
+// clients built against the previous version
+const ProtocolVersion = 1
+
+// Feature is an optional protocol capability a client can ask for in its hello
+type Feature string
+
+const (
+	FeatureDeltaUpdates Feature = "delta_updates"
+	// FeatureMsgPack switches every message after the welcome to binary
+	// MessagePack frames, in both directions
+	FeatureMsgPack Feature = "msgpack"
+)
+
+// supportedFeatures is the compatibility matrix: the protocol versions the
+// server still speaks and the features available with each of them
+var supportedFeatures = map[int][]Feature{
+	1: {FeatureDeltaUpdates, FeatureMsgPack},
+}
+
+// Encoding is the wire format a connection uses once the handshake is done
+type Encoding string
+
+const (
+	EncodingJSON    Encoding = "json"
+	EncodingMsgPack Encoding = "msgpack"
+)
+
+// IsSupportedVersion reports whether the server can talk to a client on the given version
+func IsSupportedVersion(version int) bool {
+	_, exists := supportedFeatures[version]
+	return exists
+}
+
+// NegotiateFeatures returns the requested features the server supports for the given version
+func NegotiateFeatures(version int, requested []Feature) []Feature {
+	negotiated := []Feature{}
+	for _, feature := range requested {
+		if slices.Contains(supportedFeatures[version], feature) && !slices.Contains(negotiated, feature) {
+			negotiated = append(negotiated, feature)
+		}
+	}
+	return negotiated
+}
@@ -0,0 +1,19 @@
+package messages
+
+const (
+	MessageTypeResync MessageType = "resync"
+)
+
+// ResyncMessage asks the server for a full game state snapshot
+type ResyncMessage struct {
+	BaseMessage `json:"base_message" tstype:"BaseMessage"`
+}
+
+func NewResyncMessage(senderID string) *ResyncMessage {
+	return &ResyncMessage{
+		BaseMessage: BaseMessage{
+			Type:     MessageTypeResync,
+			SenderID: senderID,
+		},
+	}
+}
@@ -0,0 +1,26 @@
+package messages
+
+import "github.com/VincentZhao12/secret-hitler/backend/internal/views"
+
+const (
+	MessageTypeStreamerState MessageType = "streamer_state"
+)
+
+type StreamerStateMessage struct {
+	BaseMessage  `json:"base_message" tstype:"BaseMessage"`
+	GameState    views.StreamerView `json:"game_state" tstype:"StreamerView"`
+	DelaySeconds int                `json:"delay_seconds"`
+	DelayRounds  int                `json:"delay_rounds"`
+}
+
+func NewStreamerStateMessage(senderID string, gameState views.StreamerView, delaySeconds int, delayRounds int) *StreamerStateMessage {
+	return &StreamerStateMessage{
+		BaseMessage: BaseMessage{
+			Type:     MessageTypeStreamerState,
+			SenderID: senderID,
+		},
+		GameState:    gameState,
+		DelaySeconds: delaySeconds,
+		DelayRounds:  delayRounds,
+	}
+}
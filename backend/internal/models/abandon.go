@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// WaitingOn returns the indexes of the players the game can't go on without:
+// everyone disconnected who hasn't been left behind by the table
+func (state *GameState) WaitingOn() []int {
+	waiting := []int{}
+	for i, player := range state.Players {
+		if !player.IsConnected && !player.IsAbandoned {
+			waiting = append(waiting, i)
+		}
+	}
+	return waiting
+}
+
+// AbandonCandidates returns the players the game is paused for whose grace
+// period is over, so the table can vote to go on without them
+func (state *GameState) AbandonCandidates(now time.Time) []int {
+	candidates := []int{}
+	if state.Pause == nil {
+		return candidates
+	}
+	for _, waiting := range state.Pause.WaitingFor {
+		if now.Unix() >= waiting.VoteOpensAtUnix {
+			candidates = append(candidates, waiting.Index)
+		}
+	}
+	return candidates
+}
+
+// AbandonPlayer takes a player out of the game as if they were executed, except
+// that it doesn't reveal anything, so abandoning Hitler doesn't end the game
+func (state *GameState) AbandonPlayer(index int) {
+	if player := state.GetPlayer(index); player != nil {
+		player.IsExecuted = true
+		player.IsAbandoned = true
+	}
+}
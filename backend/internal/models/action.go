@@ -16,5 +16,15 @@ const (
 	ActionApproveVeto     Action = "approve_veto"
 	ActionRejectVeto      Action = "reject_veto"
 	ActionEndTurn         Action = "end_turn"
+	ActionVoteContinue    Action = "vote_continue" // Go on without players who left
+	ActionAbortGame       Action = "abort_game"
+	ActionKickPlayer      Action = "kick_player"
+	ActionTransferHost    Action = "transfer_host"
+	ActionLockLobby       Action = "lock_lobby"
+	ActionUnlockLobby     Action = "unlock_lobby"
+	ActionReorderSeats    Action = "reorder_seats"
+	ActionReadyCheck      Action = "ready_check" // Host asks everyone to confirm they're ready
+	ActionReady           Action = "ready"
+	ActionRematch         Action = "rematch" // From the host starts one, from anyone else it's a vote
 	ActionNone            Action = "none"
 )
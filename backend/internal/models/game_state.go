@@ -1,11 +1,19 @@
 package models
 
 import (
+	"maps"
 	"math/rand"
+	"slices"
 
 	"github.com/VincentZhao12/secret-hitler/backend/internal/repository"
 )
 
+// A table seats between MinPlayers and MaxPlayers players
+const (
+	MinPlayers = 5
+	MaxPlayers = 10
+)
+
 type VoteResult int
 
 const (
@@ -22,27 +30,43 @@ type ChatEntry struct {
 	SentAtUnix int64  `json:"sent_at_unix"`
 }
 
+// PolicyPiles holds the real draw and discard piles. It never leaves the
+// server, clients only ever see the pile sizes through views.PlayerView.
+type PolicyPiles struct {
+	Deck    []Card `json:"deck"`
+	Discard []Card `json:"discard"`
+}
+
 type GameState struct {
 	Players             []Player       `json:"players"`
 	PlayerIndexMap      map[string]int `json:"-"`
-	Deck                []Card         `json:"deck"`
-	Discard             []Card         `json:"discard"`
-	Board               Board          `json:"board"`
-	PresidentIndex      int            `json:"president_index"`
-	ChancellorIndex     int            `json:"chancellor_index"`
-	PrevPresidentIndex  int            `json:"prev_president_index"`
-	PrevChancellorIndex int            `json:"prev_chancellor_index"`
-	NomineeIndex        int            `json:"nominee_index"`
-	Phase               GamePhase      `json:"phase"`
-	Votes               []VoteResult   `json:"votes,omitempty"`
-	PendingAction       *Action        `json:"pending_action,omitempty"`
-	PeekedCards         []Card         `json:"peeked_cards,omitempty"`
-	PeekerIndex         int            `json:"peeker_index,omitempty"`
-	ResumeOrderIndex    int            `json:"resume_order_index,omitempty"` // Post special election
-	ResumePhase         GamePhase      `json:"resume_phase,omitempty"`
-	Winner              Team           `json:"winner,omitempty"`
-	HostID              string         `json:"host_id"`
-	ChatHistory         []ChatEntry    `json:"chat_history"`
+	PolicyPiles         `json:"piles"`
+	Board               Board           `json:"board"`
+	PresidentIndex      int             `json:"president_index"`
+	ChancellorIndex     int             `json:"chancellor_index"`
+	PrevPresidentIndex  int             `json:"prev_president_index"`
+	PrevChancellorIndex int             `json:"prev_chancellor_index"`
+	NomineeIndex        int             `json:"nominee_index"`
+	Phase               GamePhase       `json:"phase"`
+	Votes               []VoteResult    `json:"votes,omitempty"`
+	PendingAction       *Action         `json:"pending_action,omitempty"`
+	PeekedCards         []Card          `json:"peeked_cards,omitempty"`
+	PeekerIndex         int             `json:"peeker_index,omitempty"`
+	ResumeOrderIndex    int             `json:"resume_order_index,omitempty"` // Post special election
+	ResumePhase         GamePhase       `json:"resume_phase,omitempty"`
+	Pause               *PauseInfo      `json:"pause,omitempty"`
+	Winner              Team            `json:"winner,omitempty"`
+	WinReason           WinReason       `json:"win_reason,omitempty"`
+	ContinueVotes       []VoteResult    `json:"continue_votes,omitempty"` // Vote to go on without missing players
+	HostID              string          `json:"host_id"`
+	LobbyLocked         bool            `json:"lobby_locked"`
+	ReadyCheck          *ReadyCheckInfo `json:"ready_check,omitempty"`
+	ChatHistory         []ChatEntry     `json:"chat_history"`
+	Round               int             `json:"round"`
+	History             []RoundRecord   `json:"history"`
+	MatchNumber         int             `json:"match_number"`       // Counts rematches at the same table, from 1
+	StartingPresident   int             `json:"starting_president"` // First president of this match, set up ahead of time for a rematch
+	RematchVotes        []VoteResult    `json:"rematch_votes,omitempty"`
 }
 
 func createDeck() []Card {
@@ -58,10 +82,12 @@ func createDeck() []Card {
 
 func NewGameState() GameState {
 	return GameState{
-		Players:             []Player{},
-		PlayerIndexMap:      make(map[string]int),
-		Deck:                []Card{},
-		Discard:             []Card{},
+		Players:        []Player{},
+		PlayerIndexMap: make(map[string]int),
+		PolicyPiles: PolicyPiles{
+			Deck:    []Card{},
+			Discard: []Card{},
+		},
 		Board:               Board{},
 		PresidentIndex:      -1,
 		ChancellorIndex:     -1,
@@ -77,6 +103,55 @@ func NewGameState() GameState {
 		Winner:              TeamUnassigned,
 		HostID:              "",
 		ChatHistory:         []ChatEntry{},
+		Round:               0,
+		History:             []RoundRecord{},
+		MatchNumber:         1,
+		StartingPresident:   -1,
+	}
+}
+
+// Clone returns a deep copy of the game state that shares no slices or maps with the original
+func (state *GameState) Clone() GameState {
+	clone := *state
+
+	clone.Players = slices.Clone(state.Players)
+	clone.PlayerIndexMap = maps.Clone(state.PlayerIndexMap)
+	clone.Deck = slices.Clone(state.Deck)
+	clone.Discard = slices.Clone(state.Discard)
+	clone.Votes = slices.Clone(state.Votes)
+	clone.ContinueVotes = slices.Clone(state.ContinueVotes)
+	clone.RematchVotes = slices.Clone(state.RematchVotes)
+	clone.PeekedCards = slices.Clone(state.PeekedCards)
+	clone.ChatHistory = slices.Clone(state.ChatHistory)
+	clone.Board.ExecutiveActions = maps.Clone(state.Board.ExecutiveActions)
+	clone.History = slices.Clone(state.History)
+	for i := range clone.History {
+		clone.History[i].Votes = slices.Clone(state.History[i].Votes)
+	}
+
+	if state.PendingAction != nil {
+		action := *state.PendingAction
+		clone.PendingAction = &action
+	}
+	if state.ReadyCheck != nil {
+		readyCheck := *state.ReadyCheck
+		clone.ReadyCheck = &readyCheck
+	}
+	if state.Pause != nil {
+		pause := *state.Pause
+		pause.WaitingFor = slices.Clone(state.Pause.WaitingFor)
+		clone.Pause = &pause
+	}
+
+	return clone
+}
+
+// RebuildPlayerIndex recreates PlayerIndexMap from Players, which is needed
+// after the state has been read back from JSON
+func (state *GameState) RebuildPlayerIndex() {
+	state.PlayerIndexMap = make(map[string]int, len(state.Players))
+	for i, player := range state.Players {
+		state.PlayerIndexMap[player.ID] = i
 	}
 }
 
@@ -102,12 +177,24 @@ func (state *GameState) AddPlayer(id string, username string) (*Player, error) {
 		return nil, repository.ErrPlayerAlreadyExists
 	}
 
-	if len(state.Players) >= 10 {
+	if !state.AcceptsPlayers() {
+		return nil, repository.ErrGameInProgress
+	}
+
+	if state.LobbyLocked {
+		return nil, repository.ErrLobbyLocked
+	}
+
+	if len(state.Players) >= MaxPlayers {
 		return nil, repository.ErrGameFull
 	}
 
-	if state.Phase != Setup && (state.Phase == Paused && state.ResumePhase == Setup) {
-		return nil, repository.ErrGameInProgress
+	username, err := NormalizeUsername(username)
+	if err != nil {
+		return nil, err
+	}
+	if state.UsernameTaken(username) {
+		return nil, repository.ErrUsernameTaken
 	}
 
 	state.PlayerIndexMap[id] = len(state.Players)
@@ -117,7 +204,7 @@ func (state *GameState) AddPlayer(id string, username string) (*Player, error) {
 }
 
 func (state *GameState) RemovePlayer(id string) error {
-	if state.Phase != Setup {
+	if !state.InLobby() {
 		return repository.ErrGameInProgress
 	}
 
@@ -126,11 +213,25 @@ func (state *GameState) RemovePlayer(id string) error {
 		return nil
 	}
 
-	lastPlayer := state.Players[len(state.Players)-1]
-	state.Players[index] = lastPlayer
-	state.PlayerIndexMap[lastPlayer.ID] = index
-	state.Players = state.Players[:len(state.Players)-1]
-	delete(state.PlayerIndexMap, id)
+	// Everyone else keeps their place at the table
+	state.Players = slices.Delete(state.Players, index, index+1)
+	state.RebuildPlayerIndex()
+
+	return nil
+}
+
+// ReorderSeats moves the players into the given order of their current seats
+func (state *GameState) ReorderSeats(order []int) error {
+	if !state.InLobby() {
+		return repository.ErrGameInProgress
+	}
+
+	players := make([]Player, 0, len(state.Players))
+	for _, index := range order {
+		players = append(players, state.Players[index])
+	}
+	state.Players = players
+	state.RebuildPlayerIndex()
 
 	return nil
 }
@@ -167,11 +268,11 @@ func (state *GameState) AssignRoles() error {
 }
 
 func (state *GameState) StartGame() error {
-	if state.Phase != Setup {
+	if !state.InLobby() {
 		return repository.ErrGameInProgress
 	}
 
-	if len(state.Players) < 5 || len(state.Players) > 10 {
+	if len(state.Players) < MinPlayers || len(state.Players) > MaxPlayers {
 		return repository.ErrInvalidPlayerCount
 	}
 
@@ -179,9 +280,17 @@ func (state *GameState) StartGame() error {
 	if err != nil {
 		return err
 	}
+	state.EndReadyCheck()
 	state.Board = board
 	state.Phase = Nomination
-	state.PresidentIndex = rand.Intn(len(state.Players))
+	state.Round = 1
+	// A rematch rotates the first president, seats may have changed since so it wraps
+	if state.MatchNumber > 1 && state.StartingPresident >= 0 {
+		state.PresidentIndex = state.StartingPresident % len(state.Players)
+	} else {
+		state.PresidentIndex = rand.Intn(len(state.Players))
+	}
+	state.StartingPresident = state.PresidentIndex
 	state.Discard = createDeck()
 	state.Deck = []Card{}
 
@@ -204,135 +313,27 @@ func (state *GameState) ShuffleDeck() {
 	state.Discard = []Card{}
 }
 
-func (state *GameState) RevealPlayer(viewer Player, revealed Player) GameState {
-	obfuscatedState := *state
-
-	// Deep copy deck
-	obfuscatedState.Deck = append([]Card(nil), state.Deck...)
-
-	// Deep copy discard
-	obfuscatedState.Discard = append([]Card(nil), state.Discard...)
-
-	// Deep copy chat history without leaking player IDs
-	obfuscatedState.ChatHistory = make([]ChatEntry, len(state.ChatHistory))
-	for i, chat := range state.ChatHistory {
-		if chat.SenderID != viewer.ID {
-			chat.SenderID = ""
-		}
-		obfuscatedState.ChatHistory[i] = chat
-	}
-
-	// Hide peeked cards unless viewer is the peeker
-	peeker := obfuscatedState.GetPlayer(obfuscatedState.PeekerIndex)
-	if peeker != nil && viewer.ID != peeker.ID {
-		obfuscatedState.PeekedCards = nil
-	}
-
-	// Obfuscate players
-	obfuscatedState.Players = make([]Player, len(state.Players))
-	for i := range obfuscatedState.Players {
-		p := state.Players[i]
-
-		// Hide roles based on viewer/revealed relationship
-		if viewer.Role == RoleLiberal && p.ID != viewer.ID && p.ID != revealed.ID {
-			p.Role = RoleHidden
-		} else if viewer.Role == RoleFascist && p.Role == RoleLiberal && p.ID != viewer.ID && p.ID != revealed.ID {
-			p.Role = RoleHidden
-		}
-
-		// Hide IDs of other players (optional)
-		if p.ID != viewer.ID {
-			p.ID = ""
-		}
-
-		obfuscatedState.Players[i] = p
-	}
-
-	return obfuscatedState
-}
-
-func (state *GameState) ObfuscateGameState(p Player) GameState {
-	obfuscatedState := *state
-
-	if p.ID != state.HostID {
-		obfuscatedState.HostID = ""
-	}
-
-	// Obfuscate deck
-	obfuscatedState.Deck = make([]Card, len(state.Deck))
-	for i := range obfuscatedState.Deck {
-		obfuscatedState.Deck[i] = state.Deck[i]
-	}
-
-	// Obfuscate discard pile
-	obfuscatedState.Discard = make([]Card, len(state.Discard))
-	for i := range obfuscatedState.Discard {
-		obfuscatedState.Discard[i] = state.Discard[i]
-	}
-
-	// Deep copy chat history without leaking player IDs
-	obfuscatedState.ChatHistory = make([]ChatEntry, len(state.ChatHistory))
-	for i, chat := range state.ChatHistory {
-		if chat.SenderID != p.ID {
-			chat.SenderID = ""
-		}
-		obfuscatedState.ChatHistory[i] = chat
-	}
-
-	// Obfuscate hands
-	peeker := obfuscatedState.GetPlayer(obfuscatedState.PeekerIndex)
-	if obfuscatedState.PeekerIndex == -1 || peeker == nil || p.ID != peeker.ID {
-		obfuscatedState.PeekedCards = nil
-	}
-
-	// Obfuscate player information
-	obfuscatedState.Players = make([]Player, len(state.Players))
-	for i := range obfuscatedState.Players {
-		player := state.Players[i]
-		if p.Role != RoleFascist && p.ID != player.ID {
-			player.Role = RoleHidden
-		}
-
-		if p.ID != player.ID {
-			player.ID = ""
-		}
-		obfuscatedState.Players[i] = player
-	}
-
-	if state.Votes != nil && state.Phase == Election {
-		obfuscatedState.Votes = make([]VoteResult, len(state.Votes))
-		for i := range obfuscatedState.Votes {
-			if state.Players[i].ID == p.ID {
-				obfuscatedState.Votes[i] = state.Votes[i]
-			} else {
-				obfuscatedState.Votes[i] = VoteHidden
-			}
-		}
-	}
-
-	return obfuscatedState
-}
-
-func (state *GameState) EndGame(winner Team) {
+func (state *GameState) EndGame(winner Team, reason WinReason) {
 	state.Phase = GameOver
 	state.Winner = winner
+	state.WinReason = reason
 }
 
 func (state *GameState) EndGameIfNecessary() bool {
 	for _, player := range state.Players {
-		if player.Role == RoleHitler && player.IsExecuted {
-			state.EndGame(TeamLiberal)
+		if player.Role == RoleHitler && player.IsExecuted && !player.IsAbandoned {
+			state.EndGame(TeamLiberal, WinReasonHitlerExecuted)
 			return true
 		}
 	}
 
 	if state.Board.FascistPolicies >= state.Board.FascistSlots {
-		state.EndGame(TeamFascist)
+		state.EndGame(TeamFascist, WinReasonFascistPolicies)
 		return true
 	}
 
 	if state.Board.LiberalPolicies >= state.Board.LiberalSlots {
-		state.EndGame(TeamLiberal)
+		state.EndGame(TeamLiberal, WinReasonLiberalPolicies)
 		return true
 	}
 
@@ -341,6 +342,7 @@ func (state *GameState) EndGameIfNecessary() bool {
 
 func (state *GameState) NewTurn() {
 	state.Phase = Nomination
+	state.Round++
 	state.PrevPresidentIndex = state.PresidentIndex
 	state.PrevChancellorIndex = state.ChancellorIndex
 
@@ -0,0 +1,51 @@
+package models
+
+// RoundRecord is what happened in a single election: who ran, how everyone
+// voted, and what came of it
+type RoundRecord struct {
+	Round           int          `json:"round"`
+	PresidentIndex  int          `json:"president_index"`
+	ChancellorIndex int          `json:"chancellor_index"`
+	Votes           []VoteResult `json:"votes"`
+	Elected         bool         `json:"elected"`
+	EnactedPolicy   Card         `json:"enacted_policy,omitempty"`
+	ChaosPolicy     bool         `json:"chaos_policy,omitempty"` // Enacted by the election tracker
+	ExecutiveAction Action       `json:"executive_action,omitempty"`
+	ActionTarget    int          `json:"action_target"`
+}
+
+// RecordElection starts a new record once every vote for the nominee is in
+func (state *GameState) RecordElection(elected bool) {
+	state.History = append(state.History, RoundRecord{
+		Round:           state.Round,
+		PresidentIndex:  state.PresidentIndex,
+		ChancellorIndex: state.NomineeIndex,
+		Votes:           append([]VoteResult{}, state.Votes...),
+		Elected:         elected,
+		ActionTarget:    -1,
+	})
+}
+
+// RecordPolicy notes the policy enacted by the latest election
+func (state *GameState) RecordPolicy(card Card, chaos bool) {
+	if record := state.lastRecord(); record != nil {
+		record.EnactedPolicy = card
+		record.ChaosPolicy = chaos
+	}
+}
+
+// RecordExecutiveAction notes the presidential power used after the latest
+// election, target is -1 for powers without one
+func (state *GameState) RecordExecutiveAction(action Action, target int) {
+	if record := state.lastRecord(); record != nil {
+		record.ExecutiveAction = action
+		record.ActionTarget = target
+	}
+}
+
+func (state *GameState) lastRecord() *RoundRecord {
+	if len(state.History) == 0 {
+		return nil
+	}
+	return &state.History[len(state.History)-1]
+}
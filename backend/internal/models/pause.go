@@ -0,0 +1,42 @@
+package models
+
+type PauseReason string
+
+const (
+	PauseReasonDisconnected  PauseReason = "player_disconnected"
+	PauseReasonServerRestart PauseReason = "server_restart"
+)
+
+// WaitingPlayer is a missing player the game is paused for. Once
+// VoteOpensAtUnix has passed the table may vote to go on without them.
+type WaitingPlayer struct {
+	Index              int   `json:"index"`
+	DisconnectedAtUnix int64 `json:"disconnected_at_unix"`
+	VoteOpensAtUnix    int64 `json:"vote_opens_at_unix"`
+}
+
+// PauseInfo explains why a paused game is paused and who it is waiting for
+type PauseInfo struct {
+	Reason     PauseReason     `json:"reason"`
+	SinceUnix  int64           `json:"since_unix"`
+	WaitingFor []WaitingPlayer `json:"waiting_for"`
+}
+
+// NeedsPlayer reports whether the given phase can't go on without the player,
+// either because it's their turn or because their vote is still missing
+func (state *GameState) NeedsPlayer(index int, phase GamePhase) bool {
+	player := state.GetPlayer(index)
+	if player == nil || player.IsExecuted {
+		return false
+	}
+
+	switch phase {
+	case Nomination, Executive, Legislation1:
+		return index == state.PresidentIndex
+	case Legislation2:
+		return index == state.ChancellorIndex
+	case Election:
+		return index < len(state.Votes) && state.Votes[index] == VotePending
+	}
+	return false
+}
@@ -4,6 +4,7 @@ type GamePhase string
 
 const (
 	Setup        GamePhase = "setup" // TODO
+	ReadyCheck   GamePhase = "ready_check"
 	Nomination   GamePhase = "nomination"
 	Election     GamePhase = "election"
 	Legislation1 GamePhase = "legislation1"
@@ -11,11 +11,14 @@ const (
 )
 
 type Player struct {
-	ID          string     `json:"id"`
-	Username    string     `json:"username"`
-	Role        PlayerRole `json:"role"`
-	IsExecuted  bool       `json:"is_executed"`
-	IsConnected bool       `json:"is_connected"`
+	ID                 string     `json:"id"`
+	Username           string     `json:"username"`
+	Role               PlayerRole `json:"role"`
+	IsExecuted         bool       `json:"is_executed"`
+	IsConnected        bool       `json:"is_connected"`
+	IsAbandoned        bool       `json:"is_abandoned"` // Voted out by the table after leaving
+	IsReady            bool       `json:"is_ready"`
+	DisconnectedAtUnix int64      `json:"disconnected_at_unix,omitempty"`
 }
 
 func NewPlayer(id string, username string) Player {
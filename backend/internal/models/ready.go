@@ -0,0 +1,46 @@
+package models
+
+// ReadyCheckInfo tracks the ready check run before the game starts
+type ReadyCheckInfo struct {
+	StartedAtUnix int64 `json:"started_at_unix"`
+	DeadlineUnix  int64 `json:"deadline_unix"` // Players not ready by then are removed
+}
+
+// InLobby reports whether the game hasn't started yet, so players can still
+// join, leave and be moved around
+func (state *GameState) InLobby() bool {
+	return state.Phase == Setup || state.Phase == ReadyCheck
+}
+
+// AcceptsPlayers reports whether new players may take a seat. That's only
+// before the game starts, roles and votes are dealt out per seat at the start
+// so nobody can be added after. A paused game counts as the phase it will
+// resume in.
+func (state *GameState) AcceptsPlayers() bool {
+	phase := state.Phase
+	if phase == Paused {
+		phase = state.ResumePhase
+	}
+	return phase == Setup || phase == ReadyCheck
+}
+
+// AllReady reports whether every connected player has confirmed the ready
+// check. Players who aren't connected can't confirm, so they don't hold the
+// game up, and the ready check's deadline removes them from the lobby.
+func (state *GameState) AllReady() bool {
+	for _, player := range state.Players {
+		if player.IsConnected && !player.IsReady {
+			return false
+		}
+	}
+	return true
+}
+
+// EndReadyCheck goes back to an ordinary lobby and forgets who was ready
+func (state *GameState) EndReadyCheck() {
+	state.Phase = Setup
+	state.ReadyCheck = nil
+	for i := range state.Players {
+		state.Players[i].IsReady = false
+	}
+}
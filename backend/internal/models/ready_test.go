@@ -0,0 +1,110 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/repository"
+)
+
+func TestAddPlayerByPhase(t *testing.T) {
+	type test struct {
+		name        string
+		phase       GamePhase
+		resumePhase GamePhase
+		joinable    bool
+	}
+
+	phases := []GamePhase{Setup, ReadyCheck, Nomination, Election, Legislation1, Legislation2, Executive, GameOver}
+	tests := []test{}
+	for _, phase := range phases {
+		joinable := phase == Setup || phase == ReadyCheck
+		tests = append(tests,
+			test{string(phase), phase, "", joinable},
+			test{"paused in " + string(phase), Paused, phase, joinable},
+		)
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state := NewGameState()
+			for i := range 5 {
+				if _, err := state.AddPlayer(fmt.Sprintf("id%d", i), fmt.Sprintf("player%d", i)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			state.Phase = test.phase
+			state.ResumePhase = test.resumePhase
+			state.Votes = []VoteResult{VoteJa, VoteNein, VotePending, VoteJa, VotePending}
+
+			players := slices.Clone(state.Players)
+			votes := slices.Clone(state.Votes)
+
+			if accepts := state.AcceptsPlayers(); accepts != test.joinable {
+				t.Errorf("AcceptsPlayers() = %v, want %v", accepts, test.joinable)
+			}
+
+			player, err := state.AddPlayer("newcomer", "newcomer")
+			if test.joinable {
+				if err != nil {
+					t.Fatalf("AddPlayer() failed: %v", err)
+				}
+				if player.ID != "newcomer" || len(state.Players) != len(players)+1 || state.PlayerIndexMap["newcomer"] != len(players) {
+					t.Errorf("newcomer wasn't seated, players %+v", state.Players)
+				}
+			} else {
+				if !errors.Is(err, repository.ErrGameInProgress) {
+					t.Errorf("AddPlayer() error = %v, want %v", err, repository.ErrGameInProgress)
+				}
+				if player != nil {
+					t.Errorf("AddPlayer() returned a player for a refused join")
+				}
+				if !reflect.DeepEqual(state.Players, players) {
+					t.Errorf("players changed by a refused join\nwant %+v\ngot  %+v", players, state.Players)
+				}
+				if _, exists := state.PlayerIndexMap["newcomer"]; exists {
+					t.Errorf("refused newcomer is in the player index")
+				}
+			}
+
+			if !reflect.DeepEqual(state.Votes, votes) {
+				t.Errorf("votes changed by a join\nwant %v\ngot  %v", votes, state.Votes)
+			}
+		})
+	}
+}
+
+func TestAllReady(t *testing.T) {
+	tests := []struct {
+		name      string
+		ready     []bool
+		connected []bool
+		want      bool
+	}{
+		{"everyone ready", []bool{true, true, true, true, true}, []bool{true, true, true, true, true}, true},
+		{"one not ready", []bool{true, true, false, true, true}, []bool{true, true, true, true, true}, false},
+		{"disconnected and ready", []bool{true, true, true, true, true}, []bool{true, false, true, true, true}, true},
+		{"disconnected and not ready", []bool{true, false, true, true, true}, []bool{true, false, true, true, true}, true},
+		{"connected and not ready", []bool{true, false, false, true, true}, []bool{true, false, true, true, true}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state := NewGameState()
+			for i := range test.ready {
+				if _, err := state.AddPlayer(fmt.Sprintf("id%d", i), fmt.Sprintf("player%d", i)); err != nil {
+					t.Fatal(err)
+				}
+				state.Players[i].IsReady = test.ready[i]
+				state.Players[i].IsConnected = test.connected[i]
+			}
+
+			if got := state.AllReady(); got != test.want {
+				t.Errorf("AllReady() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
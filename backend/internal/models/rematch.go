@@ -0,0 +1,58 @@
+package models
+
+import "github.com/VincentZhao12/secret-hitler/backend/internal/repository"
+
+// Rematch sets the table up for another game once this one is over. Everyone
+// keeps their ID, seat and host status, the chat carries over, and the next
+// game's first president is the seat after this one's.
+func (state *GameState) Rematch() error {
+	if state.Phase != GameOver {
+		return repository.ErrGameNotOver
+	}
+
+	next := NewGameState()
+	next.MatchNumber = max(state.MatchNumber, 1) + 1
+	if len(state.Players) > 0 && state.StartingPresident >= 0 {
+		next.StartingPresident = (state.StartingPresident + 1) % len(state.Players)
+	}
+	next.HostID = state.HostID
+	next.LobbyLocked = state.LobbyLocked
+	next.ChatHistory = state.ChatHistory
+
+	for _, player := range state.Players {
+		fresh := NewPlayer(player.ID, player.Username)
+		fresh.IsConnected = player.IsConnected
+		fresh.DisconnectedAtUnix = player.DisconnectedAtUnix
+		next.Players = append(next.Players, fresh)
+	}
+	next.RebuildPlayerIndex()
+
+	*state = next
+	return nil
+}
+
+// RematchVote records a player's vote for a rematch and reports whether most
+// of the players still connected want one
+func (state *GameState) RematchVote(index int, yes bool) bool {
+	if state.RematchVotes == nil {
+		state.RematchVotes = make([]VoteResult, len(state.Players))
+	}
+	if yes {
+		state.RematchVotes[index] = VoteJa
+	} else {
+		state.RematchVotes[index] = VoteNein
+	}
+
+	eligibleVoters := 0
+	yesVotes := 0
+	for i, player := range state.Players {
+		if !player.IsConnected {
+			continue
+		}
+		eligibleVoters++
+		if state.RematchVotes[i] == VoteJa {
+			yesVotes++
+		}
+	}
+	return yesVotes > eligibleVoters/2
+}
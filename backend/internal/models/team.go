@@ -7,3 +7,15 @@ const (
 	TeamFascist    Team = "FASCISTS"
 	TeamLiberal    Team = "LIBERALS"
 )
+
+// WinReason is how the game was decided
+type WinReason string
+
+const (
+	WinReasonNone            WinReason = ""
+	WinReasonLiberalPolicies WinReason = "liberal_policies"
+	WinReasonFascistPolicies WinReason = "fascist_policies"
+	WinReasonHitlerExecuted  WinReason = "hitler_executed"
+	WinReasonHitlerElected   WinReason = "hitler_elected"
+	WinReasonAborted         WinReason = "aborted" // Ended early by the host, nobody wins
+)
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/repository"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	MinUsernameLength = 2
+	MaxUsernameLength = 20
+)
+
+// Names players could use to pass themselves off as the game itself
+var reservedUsernames = []string{"server", "system", "admin", "administrator", "moderator", "host", "everyone"}
+
+// NormalizeUsername cleans up a username and checks it is one players may use.
+// Whitespace is trimmed and collapsed, the result is NFC normalized, and only
+// letters, digits, spaces and a little punctuation are allowed.
+func NormalizeUsername(username string) (string, error) {
+	if !utf8.ValidString(username) {
+		return "", fmt.Errorf("%w: not valid text", repository.ErrInvalidUsername)
+	}
+
+	// Checked before doing any work on it, normalizing can't shrink it this much
+	if len(username) > MaxUsernameLength*utf8.UTFMax*2 {
+		return "", fmt.Errorf("%w: must be at most %d characters", repository.ErrInvalidUsername, MaxUsernameLength)
+	}
+
+	username = norm.NFC.String(strings.Join(strings.Fields(username), " "))
+
+	length := utf8.RuneCountInString(username)
+	if length < MinUsernameLength || length > MaxUsernameLength {
+		return "", fmt.Errorf("%w: must be between %d and %d characters", repository.ErrInvalidUsername, MinUsernameLength, MaxUsernameLength)
+	}
+
+	for _, r := range username {
+		if !usernameRune(r) {
+			return "", fmt.Errorf("%w: %q is not allowed", repository.ErrInvalidUsername, r)
+		}
+	}
+
+	if slices.Contains(reservedUsernames, reservedKey(username)) {
+		return "", repository.ErrReservedUsername
+	}
+
+	return username, nil
+}
+
+func usernameRune(r rune) bool {
+	switch {
+	case unicode.IsLetter(r), unicode.IsDigit(r), unicode.Is(unicode.Mn, r):
+		return true
+	case r == ' ', r == '_', r == '-', r == '.', r == '\'':
+		return true
+	}
+	return false
+}
+
+// UsernameKey is what two usernames are compared by, so "Alice" and "ＡＬＩＣＥ"
+// count as the same name
+func UsernameKey(username string) string {
+	return strings.ToLower(norm.NFKC.String(username))
+}
+
+// reservedKey strips everything but letters and digits, so "S.e.r.v.e.r" is
+// caught as well
+func reservedKey(username string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, UsernameKey(username))
+}
+
+// UsernameTaken reports whether someone at the table already goes by the name
+func (state *GameState) UsernameTaken(username string) bool {
+	key := UsernameKey(username)
+	return slices.ContainsFunc(state.Players, func(player Player) bool {
+		return UsernameKey(player.Username) == key
+	})
+}
@@ -8,4 +8,19 @@ var (
 	ErrGameFull            = errors.New("game is full")
 	ErrGameInProgress      = errors.New("game is in progress")
 	ErrPlayerNotFound      = errors.New("player not found")
+	ErrGameClosed          = errors.New("game is closed")
+	ErrLobbyLocked         = errors.New("lobby is locked")
+	ErrNotHost             = errors.New("only the host can do that")
+	ErrWrongPassword       = errors.New("wrong password")
+	ErrInviteRequired      = errors.New("game is invite only")
+	ErrInvalidInvite       = errors.New("invite is invalid or already used")
+	ErrInvalidVisibility   = errors.New("visibility must be public or private")
+	ErrInvalidTableSize    = errors.New("table size must be between 5 and 10")
+	ErrMatchmakingClosed   = errors.New("matchmaking is not running")
+	ErrTicketNotFound      = errors.New("matchmaking ticket not found")
+	ErrTicketExpired       = errors.New("no table was found in time")
+	ErrInvalidUsername     = errors.New("invalid username")
+	ErrReservedUsername    = errors.New("username is reserved")
+	ErrUsernameTaken       = errors.New("username is already taken in this game")
+	ErrGameNotOver         = errors.New("game is not over")
 )
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const (
+	snapshotExt = ".json"
+	eventsExt   = ".events.jsonl"
+	archiveDir  = "archive"
+)
+
+// FileStore keeps one snapshot file and one JSON lines event log per game in a
+// directory, which makes games easy to inspect with nothing but a text editor.
+// Summaries of finished games go in an archive subdirectory and outlive both.
+type FileStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(filepath.Join(dir, archiveDir), 0o755); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) path(id string, ext string) string {
+	// IDs come from clients in places, so never let one escape the directory
+	return filepath.Join(s.dir, filepath.Base(id)+ext)
+}
+
+func (s *FileStore) SaveSnapshot(id string, snapshot []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return writeAtomic(s.path(id, snapshotExt), snapshot)
+}
+
+// writeAtomic writes then renames so a crash never leaves half a file behind
+func writeAtomic(path string, data []byte) error {
+	if err := os.WriteFile(path+".tmp", data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+func (s *FileStore) LoadSnapshot(id string) ([]byte, error) {
+	snapshot, err := os.ReadFile(s.path(id, snapshotExt))
+	if os.IsNotExist(err) {
+		return nil, ErrGameNotFound
+	}
+	return snapshot, err
+}
+
+func (s *FileStore) ListSnapshots() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasSuffix(name, eventsExt) || !strings.HasSuffix(name, snapshotExt) {
+			continue
+		}
+		ids = append(ids, strings.TrimSuffix(name, snapshotExt))
+	}
+	slices.Sort(ids)
+	return ids, nil
+}
+
+func (s *FileStore) DeleteSnapshot(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, ext := range []string{snapshotExt, eventsExt} {
+		if err := os.Remove(s.path(id, ext)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *FileStore) AppendEvent(id string, event Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.OpenFile(s.path(id, eventsExt), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
+
+func (s *FileStore) Events(id string) ([]Event, error) {
+	file, err := os.Open(s.path(id, eventsExt))
+	if os.IsNotExist(err) {
+		return []Event{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	events := []Event{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			// A crash mid-append can leave a torn last line
+			continue
+		}
+		events = append(events, event)
+	}
+	return events, scanner.Err()
+}
+
+// TrimEvents rewrites the log with only its newest events. Lines are kept as
+// they are, torn ones included, since they are never decoded here.
+func (s *FileStore) TrimEvents(id string, keep int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := s.path(id, eventsExt)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	lines := bytes.SplitAfter(data, []byte{'\n'})
+	if len(lines) > 0 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) <= keep {
+		return nil
+	}
+	return writeAtomic(path, bytes.Join(lines[len(lines)-keep:], nil))
+}
+
+func (s *FileStore) archivePath(id string) string {
+	return filepath.Join(s.dir, archiveDir, filepath.Base(id)+snapshotExt)
+}
+
+func (s *FileStore) SaveArchive(id string, summary []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return writeAtomic(s.archivePath(id), summary)
+}
+
+func (s *FileStore) LoadArchive(id string) ([]byte, error) {
+	summary, err := os.ReadFile(s.archivePath(id))
+	if os.IsNotExist(err) {
+		return nil, ErrGameNotFound
+	}
+	return summary, err
+}
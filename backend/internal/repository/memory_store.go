@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"slices"
+	"sync"
+)
+
+// MemoryStore keeps everything in memory, so nothing survives a restart
+type MemoryStore struct {
+	snapshots map[string][]byte
+	events    map[string][]Event
+	archive   map[string][]byte
+	mu        sync.RWMutex
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		snapshots: make(map[string][]byte),
+		events:    make(map[string][]Event),
+		archive:   make(map[string][]byte),
+	}
+}
+
+func (s *MemoryStore) SaveSnapshot(id string, snapshot []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.snapshots[id] = slices.Clone(snapshot)
+	return nil
+}
+
+func (s *MemoryStore) LoadSnapshot(id string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	snapshot, exists := s.snapshots[id]
+	if !exists {
+		return nil, ErrGameNotFound
+	}
+	return slices.Clone(snapshot), nil
+}
+
+func (s *MemoryStore) ListSnapshots() ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ids := make([]string, 0, len(s.snapshots))
+	for id := range s.snapshots {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids, nil
+}
+
+func (s *MemoryStore) DeleteSnapshot(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.snapshots, id)
+	delete(s.events, id)
+	return nil
+}
+
+func (s *MemoryStore) AppendEvent(id string, event Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events[id] = append(s.events[id], event)
+	return nil
+}
+
+func (s *MemoryStore) Events(id string) ([]Event, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.events[id]), nil
+}
+
+func (s *MemoryStore) TrimEvents(id string, keep int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if events := s.events[id]; len(events) > keep {
+		s.events[id] = slices.Clone(events[len(events)-keep:])
+	}
+	return nil
+}
+
+func (s *MemoryStore) SaveArchive(id string, summary []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.archive[id] = slices.Clone(summary)
+	return nil
+}
+
+func (s *MemoryStore) LoadArchive(id string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	summary, exists := s.archive[id]
+	if !exists {
+		return nil, ErrGameNotFound
+	}
+	return slices.Clone(summary), nil
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var ErrGameNotFound = errors.New("game not found")
+
+// Event is a single entry in a game's log. The log is only appended to, apart
+// from dropping its oldest entries once it grows too long.
+type Event struct {
+	Type string          `json:"type"`
+	At   time.Time       `json:"at"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// GameStore persists game snapshots and event logs, and archives the summaries
+// of finished games. Snapshots and summaries are opaque to the store, it only
+// ever sees the encoded bytes.
+type GameStore interface {
+	SaveSnapshot(id string, snapshot []byte) error
+	LoadSnapshot(id string) ([]byte, error)
+	ListSnapshots() ([]string, error)
+	DeleteSnapshot(id string) error
+	AppendEvent(id string, event Event) error
+	Events(id string) ([]Event, error)
+	TrimEvents(id string, keep int) error // Drops all but the newest keep events
+	SaveArchive(id string, summary []byte) error
+	LoadArchive(id string) ([]byte, error)
+}
@@ -16,7 +16,7 @@ import (
 	"github.com/go-chi/cors"
 )
 
-func SetupRouter(m *game.Manager, env envs.Env) http.Handler {
+func SetupRouter(m *game.Manager, env envs.Env, clientIPHeader string) http.Handler {
 	r := chi.NewRouter()
 
 	if env == envs.Development {
@@ -31,21 +31,41 @@ func SetupRouter(m *game.Manager, env envs.Env) http.Handler {
 	}
 
 	r.Use(middleware.RequestID)
-	r.Use(middleware.RealIP)
+	// RealIP would trust forwarding headers from anyone, so only the header our
+	// proxy sets is believed
+	r.Use(handlers.ClientIP(clientIPHeader))
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
-	r.Use(middleware.Timeout(60 * time.Second))
 
 	r.Route("/api/v1", func(api chi.Router) {
-		api.Post("/games/create", handlers.CreateGame(m))
-		api.Post("/games/join", handlers.JoinGame(m))
+		api.Group(func(api chi.Router) {
+			api.Use(middleware.Timeout(60 * time.Second))
+			api.Get("/metrics", handlers.ServerMetrics(m))
+			api.Get("/games", handlers.ListGames(m))
+			api.Post("/games/create", handlers.CreateGame(m))
+			api.Post("/games/join", handlers.JoinGame(m))
+			api.Post("/games/{id}/invites", handlers.CreateInvite(m))
+			api.Post("/matchmaking/enqueue", handlers.Enqueue(m))
+			api.Post("/games/{id}/actions", handlers.PostAction(m))
+			api.Get("/games/{id}/metrics", handlers.GameMetrics(m))
+			api.Get("/games/{id}/summary", handlers.GameSummary(m))
+		})
+
+		// Long-lived streams must not be cut off by the request timeout
 		api.Get("/play", handlers.Play(m))
+		api.Get("/spectate", handlers.Spectate(m))
+		api.Get("/games/feed", handlers.LobbyFeed(m))
+		api.Get("/matchmaking", handlers.Matchmaking(m))
+		api.Get("/games/{id}/events", handlers.PlayEvents(m))
 	})
 
 	// Serve static files from web/dist
 	workDir, _ := filepath.Abs("./static")
 	filesDir := http.Dir(workDir)
-	FileServer(r, "/", filesDir)
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.Timeout(60 * time.Second))
+		FileServer(r, "/", filesDir)
+	})
 
 	return r
 }
@@ -0,0 +1,14 @@
+package views
+
+// LobbyListing is what the public lobby browser shows about a game waiting
+// for players
+type LobbyListing struct {
+	GameID            string `json:"game_id"`
+	HostName          string `json:"host_name"`
+	PlayerCount       int    `json:"player_count"`
+	MaxPlayers        int    `json:"max_players"`
+	Variant           string `json:"variant"`
+	PasswordProtected bool   `json:"password_protected"`
+	Streamed          bool   `json:"streamed"`
+	CreatedAtUnix     int64  `json:"created_at_unix"`
+}
@@ -0,0 +1,197 @@
+package views
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/models"
+)
+
+// PlayerInfo is what a recipient may know about a single seat at the table
+type PlayerInfo struct {
+	ID          string            `json:"id"`
+	Username    string            `json:"username"`
+	Role        models.PlayerRole `json:"role" tstype:"PlayerRole"`
+	IsExecuted  bool              `json:"is_executed"`
+	IsConnected bool              `json:"is_connected"`
+	IsAbandoned bool              `json:"is_abandoned"`
+	IsReady     bool              `json:"is_ready"`
+}
+
+type ChatMessage struct {
+	SenderID   string `json:"sender_id"`
+	SenderName string `json:"sender_name"`
+	Text       string `json:"text"`
+	SentAtUnix int64  `json:"sent_at_unix"`
+}
+
+// PlayerView is the game as seen by one player. Every field is filled in
+// explicitly from the domain state, so anything added to models.GameState stays
+// on the server until it is deliberately added here.
+type PlayerView struct {
+	Players             []PlayerInfo           `json:"players"`
+	DeckSize            int                    `json:"deck_size"`
+	DiscardSize         int                    `json:"discard_size"`
+	Board               models.Board           `json:"board" tstype:"Board"`
+	PresidentIndex      int                    `json:"president_index"`
+	ChancellorIndex     int                    `json:"chancellor_index"`
+	PrevPresidentIndex  int                    `json:"prev_president_index"`
+	PrevChancellorIndex int                    `json:"prev_chancellor_index"`
+	NomineeIndex        int                    `json:"nominee_index"`
+	Phase               models.GamePhase       `json:"phase" tstype:"GamePhase"`
+	Votes               []models.VoteResult    `json:"votes,omitempty" tstype:"VoteResult[]"`
+	PendingAction       *models.Action         `json:"pending_action,omitempty" tstype:"Action"`
+	PeekedCards         []models.Card          `json:"peeked_cards,omitempty" tstype:"Card[]"`
+	PeekerIndex         int                    `json:"peeker_index,omitempty"`
+	ResumeOrderIndex    int                    `json:"resume_order_index,omitempty"` // Post special election
+	ResumePhase         models.GamePhase       `json:"resume_phase,omitempty" tstype:"GamePhase"`
+	Pause               *models.PauseInfo      `json:"pause,omitempty" tstype:"PauseInfo"`
+	Winner              models.Team            `json:"winner,omitempty" tstype:"Team"`
+	WinReason           models.WinReason       `json:"win_reason,omitempty" tstype:"WinReason"`
+	ContinueVotes       []models.VoteResult    `json:"continue_votes,omitempty" tstype:"VoteResult[]"`
+	HostID              string                 `json:"host_id"`
+	HostIndex           int                    `json:"host_index"`
+	LobbyLocked         bool                   `json:"lobby_locked"`
+	ReadyCheck          *models.ReadyCheckInfo `json:"ready_check,omitempty" tstype:"ReadyCheckInfo"`
+	ChatHistory         []ChatMessage          `json:"chat_history"`
+	Round               int                    `json:"round"`
+	MatchNumber         int                    `json:"match_number"`
+	RematchVotes        []models.VoteResult    `json:"rematch_votes,omitempty" tstype:"VoteResult[]"`
+}
+
+// ForPlayer builds the view of the game for the player with the given ID.
+// Unknown IDs get the view of an outsider, which reveals nothing private.
+func ForPlayer(state *models.GameState, viewerID string) PlayerView {
+	return build(state, viewerID, -1)
+}
+
+// WithInvestigation is ForPlayer plus the party membership of the investigated
+// player. Only party is revealed, so Hitler shows up as a fascist.
+func WithInvestigation(state *models.GameState, viewerID string, investigatedIndex int) PlayerView {
+	return build(state, viewerID, investigatedIndex)
+}
+
+func build(state *models.GameState, viewerID string, investigatedIndex int) PlayerView {
+	viewer := state.GetPlayerByID(viewerID)
+	gameOver := state.Phase == models.GameOver
+
+	view := PlayerView{
+		Players:             make([]PlayerInfo, len(state.Players)),
+		DeckSize:            len(state.Deck),
+		DiscardSize:         len(state.Discard),
+		Board:               copyBoard(state.Board),
+		PresidentIndex:      state.PresidentIndex,
+		ChancellorIndex:     state.ChancellorIndex,
+		PrevPresidentIndex:  state.PrevPresidentIndex,
+		PrevChancellorIndex: state.PrevChancellorIndex,
+		NomineeIndex:        state.NomineeIndex,
+		Phase:               state.Phase,
+		PeekerIndex:         state.PeekerIndex,
+		ResumeOrderIndex:    state.ResumeOrderIndex,
+		ResumePhase:         state.ResumePhase,
+		Winner:              state.Winner,
+		WinReason:           state.WinReason,
+		ContinueVotes:       slices.Clone(state.ContinueVotes),
+		HostIndex:           -1,
+		LobbyLocked:         state.LobbyLocked,
+		ChatHistory:         make([]ChatMessage, len(state.ChatHistory)),
+		Round:               state.Round,
+		MatchNumber:         state.MatchNumber,
+		RematchVotes:        slices.Clone(state.RematchVotes),
+	}
+
+	if index, exists := state.PlayerIndexMap[state.HostID]; exists {
+		view.HostIndex = index
+	}
+	if viewer != nil && viewer.ID == state.HostID {
+		view.HostID = state.HostID
+	}
+
+	if state.PendingAction != nil {
+		action := *state.PendingAction
+		view.PendingAction = &action
+	}
+	if state.ReadyCheck != nil {
+		readyCheck := *state.ReadyCheck
+		view.ReadyCheck = &readyCheck
+	}
+	if state.Pause != nil {
+		pause := *state.Pause
+		pause.WaitingFor = slices.Clone(state.Pause.WaitingFor)
+		view.Pause = &pause
+	}
+
+	for i, player := range state.Players {
+		info := PlayerInfo{
+			Username:    player.Username,
+			Role:        models.RoleHidden,
+			IsExecuted:  player.IsExecuted,
+			IsConnected: player.IsConnected,
+			IsAbandoned: player.IsAbandoned,
+			IsReady:     player.IsReady,
+		}
+
+		isViewer := viewer != nil && player.ID == viewer.ID
+		if isViewer {
+			info.ID = player.ID
+		}
+
+		switch {
+		case gameOver, isViewer, viewer != nil && viewer.Role == models.RoleFascist:
+			info.Role = player.Role
+		case i == investigatedIndex:
+			info.Role = partyOf(player.Role)
+		}
+		// Before roles are dealt there is nothing to hide
+		if player.Role == models.RoleUnassigned {
+			info.Role = models.RoleUnassigned
+		}
+
+		view.Players[i] = info
+	}
+
+	if state.Votes != nil {
+		// An election paused for a missing voter is still an election
+		voting := state.Phase == models.Election || (state.Phase == models.Paused && state.ResumePhase == models.Election)
+		view.Votes = make([]models.VoteResult, len(state.Votes))
+		for i, vote := range state.Votes {
+			isViewer := viewer != nil && i == state.PlayerIndexMap[viewer.ID]
+			// Votes are secret until everyone has voted and the phase moves on
+			if voting && !isViewer {
+				vote = models.VoteHidden
+			}
+			view.Votes[i] = vote
+		}
+	}
+
+	peeker := state.GetPlayer(state.PeekerIndex)
+	if peeker != nil && viewer != nil && peeker.ID == viewer.ID && state.PeekedCards != nil {
+		view.PeekedCards = append([]models.Card{}, state.PeekedCards...)
+	}
+
+	for i, chat := range state.ChatHistory {
+		message := ChatMessage{
+			SenderName: chat.SenderName,
+			Text:       chat.Text,
+			SentAtUnix: chat.SentAtUnix,
+		}
+		if viewer != nil && chat.SenderID == viewer.ID {
+			message.SenderID = chat.SenderID
+		}
+		view.ChatHistory[i] = message
+	}
+
+	return view
+}
+
+func partyOf(role models.PlayerRole) models.PlayerRole {
+	if role == models.RoleHitler {
+		return models.RoleFascist
+	}
+	return role
+}
+
+func copyBoard(board models.Board) models.Board {
+	board.ExecutiveActions = maps.Clone(board.ExecutiveActions)
+	return board
+}
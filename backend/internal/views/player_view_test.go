@@ -0,0 +1,171 @@
+package views
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/models"
+)
+
+var fuzzPhases = []models.GamePhase{
+	models.Setup,
+	models.ReadyCheck,
+	models.Nomination,
+	models.Election,
+	models.Legislation1,
+	models.Legislation2,
+	models.Executive,
+	models.Paused,
+	models.GameOver,
+}
+
+// randomState builds an arbitrary but well formed game from the seed
+func randomState(rng *rand.Rand) models.GameState {
+	state := models.NewGameState()
+	count := models.MinPlayers + rng.Intn(models.MaxPlayers-models.MinPlayers+1)
+	for i := range count {
+		id := fmt.Sprintf("ID%016x", rng.Uint64())
+		if _, err := state.AddPlayer(id, fmt.Sprintf("player%d", i)); err != nil {
+			panic(err)
+		}
+	}
+	state.HostID = state.Players[rng.Intn(count)].ID
+
+	state.Phase = fuzzPhases[rng.Intn(len(fuzzPhases))]
+	if state.Phase == models.Paused {
+		state.ResumePhase = fuzzPhases[2+rng.Intn(5)]
+	}
+	if state.Phase == models.Setup || state.Phase == models.ReadyCheck {
+		return state
+	}
+
+	// Dealt the usual way, then put in an order that only depends on the seed
+	state.AssignRoles()
+	roles := []models.PlayerRole{}
+	for _, player := range state.Players {
+		roles = append(roles, player.Role)
+	}
+	slices.Sort(roles)
+	rng.Shuffle(len(roles), func(i, j int) { roles[i], roles[j] = roles[j], roles[i] })
+	for i := range state.Players {
+		state.Players[i].Role = roles[i]
+		state.Players[i].IsExecuted = rng.Intn(6) == 0
+		state.Players[i].IsConnected = rng.Intn(4) != 0
+	}
+
+	state.PresidentIndex = rng.Intn(count)
+	state.ChancellorIndex = rng.Intn(count)
+	state.NomineeIndex = rng.Intn(count)
+
+	for range 3 + rng.Intn(15) {
+		state.Deck = append(state.Deck, []models.Card{models.CardLiberal, models.CardFascist}[rng.Intn(2)])
+	}
+	state.Votes = make([]models.VoteResult, count)
+	for i := range state.Votes {
+		state.Votes[i] = []models.VoteResult{models.VotePending, models.VoteJa, models.VoteNein}[rng.Intn(3)]
+	}
+	if rng.Intn(2) == 0 {
+		state.PeekerIndex = rng.Intn(count)
+		state.PeekedCards = state.Deck[:1+rng.Intn(3)]
+	}
+	for i := range rng.Intn(5) {
+		sender := state.Players[rng.Intn(count)]
+		state.ChatHistory = append(state.ChatHistory, models.ChatEntry{
+			SenderID:   sender.ID,
+			SenderName: sender.Username,
+			Text:       fmt.Sprintf("message %d", i),
+		})
+	}
+	return state
+}
+
+// checkView asserts the view shows the viewer nothing the rules keep from them
+func checkView(t *testing.T, state *models.GameState, viewerIndex int, investigated int, view PlayerView) {
+	t.Helper()
+
+	data, err := json.Marshal(view)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"deck", "discard", "piles"} {
+		if _, exists := fields[key]; exists {
+			t.Errorf("view has the %q pile", key)
+		}
+	}
+
+	var decoded PlayerView
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	viewer := state.GetPlayer(viewerIndex)
+	gameOver := state.Phase == models.GameOver
+	seesFascists := viewer != nil && viewer.Role == models.RoleFascist
+	electionPhase := state.Phase
+	if electionPhase == models.Paused {
+		electionPhase = state.ResumePhase
+	}
+
+	for i, player := range state.Players {
+		if i == viewerIndex {
+			continue
+		}
+
+		if bytes.Contains(data, []byte(player.ID)) {
+			t.Errorf("viewer %d sees the ID of player %d", viewerIndex, i)
+		}
+
+		role := decoded.Players[i].Role
+		switch {
+		case player.Role == models.RoleUnassigned, gameOver, seesFascists:
+			// Nothing to hide, or the rules reveal it
+		case i == investigated:
+			if role != models.RoleHidden && role != partyOf(player.Role) {
+				t.Errorf("investigation of player %d reveals %q", i, role)
+			}
+		case role != models.RoleHidden:
+			t.Errorf("viewer %d sees the role of player %d: %q", viewerIndex, i, role)
+		}
+
+		if electionPhase == models.Election && decoded.Votes != nil && decoded.Votes[i] != models.VoteHidden {
+			t.Errorf("viewer %d sees the pending vote of player %d in %s", viewerIndex, i, state.Phase)
+		}
+	}
+
+	if viewerIndex != state.PeekerIndex && len(decoded.PeekedCards) > 0 {
+		t.Errorf("viewer %d sees the cards peeked by player %d", viewerIndex, state.PeekerIndex)
+	}
+}
+
+func FuzzForPlayer(f *testing.F) {
+	for seed := range int64(64) {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, seed int64) {
+		rng := rand.New(rand.NewSource(seed))
+		state := randomState(rng)
+
+		// Every seat plus an outsider, who matches no seat
+		for viewerIndex := -1; viewerIndex < len(state.Players); viewerIndex++ {
+			viewerID := "outsider"
+			if viewerIndex >= 0 {
+				viewerID = state.Players[viewerIndex].ID
+			}
+
+			checkView(t, &state, viewerIndex, -1, ForPlayer(&state, viewerID))
+
+			investigated := rng.Intn(len(state.Players))
+			checkView(t, &state, viewerIndex, investigated, WithInvestigation(&state, viewerID, investigated))
+		}
+	})
+}
@@ -0,0 +1,32 @@
+package views
+
+import (
+	"slices"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/models"
+)
+
+// StreamerView reveals every role and card for the delayed streamer feed. It
+// still carries no player IDs so the feed can't be used to impersonate anyone.
+type StreamerView struct {
+	PlayerView `tstype:",extends"`
+	Deck       []models.Card `json:"deck" tstype:"Card[]"`
+	Discard    []models.Card `json:"discard" tstype:"Card[]"`
+}
+
+func ForStreamer(state *models.GameState) StreamerView {
+	// An outsider's view hides everything private, which is then filled back in
+	view := StreamerView{
+		PlayerView: build(state, "", -1),
+		Deck:       slices.Clone(state.Deck),
+		Discard:    slices.Clone(state.Discard),
+	}
+
+	for i, player := range state.Players {
+		view.Players[i].Role = player.Role
+	}
+	view.Votes = slices.Clone(state.Votes)
+	view.PeekedCards = slices.Clone(state.PeekedCards)
+
+	return view
+}
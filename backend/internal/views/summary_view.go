@@ -0,0 +1,48 @@
+package views
+
+import (
+	"slices"
+	"time"
+
+	"github.com/VincentZhao12/secret-hitler/backend/internal/models"
+)
+
+// GameSummary is the post-game record kept after the live game is gone. Every
+// role is revealed, but like the other views it carries no player IDs.
+type GameSummary struct {
+	GameID         string               `json:"game_id"`
+	MatchNumber    int                  `json:"match_number"`
+	Winner         models.Team          `json:"winner" tstype:"Team"`
+	WinReason      models.WinReason     `json:"win_reason" tstype:"WinReason"`
+	Players        []PlayerInfo         `json:"players"`
+	Board          models.Board         `json:"board" tstype:"Board"`
+	Rounds         []models.RoundRecord `json:"rounds" tstype:"RoundRecord[]"`
+	ChatHistory    []ChatMessage        `json:"chat_history"`
+	FinishedAtUnix int64                `json:"finished_at_unix"`
+}
+
+func ForSummary(gameID string, state *models.GameState, finishedAt time.Time) GameSummary {
+	view := build(state, "", -1)
+
+	// Roles are all revealed at game over, but not if the game was cut short
+	for i, player := range state.Players {
+		view.Players[i].Role = player.Role
+	}
+
+	rounds := slices.Clone(state.History)
+	for i := range rounds {
+		rounds[i].Votes = slices.Clone(rounds[i].Votes)
+	}
+
+	return GameSummary{
+		GameID:         gameID,
+		MatchNumber:    max(state.MatchNumber, 1),
+		Winner:         state.Winner,
+		WinReason:      state.WinReason,
+		Players:        view.Players,
+		Board:          view.Board,
+		Rounds:         rounds,
+		ChatHistory:    view.ChatHistory,
+		FinishedAtUnix: finishedAt.Unix(),
+	}
+}
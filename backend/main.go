@@ -1,18 +1,71 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/VincentZhao12/secret-hitler/backend/internal/envs"
 	"github.com/VincentZhao12/secret-hitler/backend/internal/game"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/handlers"
+	"github.com/VincentZhao12/secret-hitler/backend/internal/repository"
 	"github.com/VincentZhao12/secret-hitler/backend/internal/routes"
 )
 
 func main() {
 	env := envs.GetEnv()
-	m := game.NewManager()
-	r := routes.SetupRouter(m, env)
-	fmt.Println("Listening on :8080")
-	http.ListenAndServe(":8080", r)
+	handlers.ConfigureWebsocket(envs.GetWebsocketConfig())
+	store, err := newStore(envs.GetStoreConfig())
+	if err != nil {
+		fmt.Println("Failed to open game store:", err)
+		os.Exit(1)
+	}
+	m := game.NewManager(store, envs.GetGameConfig())
+
+	restored, err := m.RestoreGames()
+	if err != nil {
+		fmt.Println("Failed to restore games:", err)
+	}
+	fmt.Println("Restored", restored, "games")
+	m.StartJanitor(envs.GetJanitorConfig())
+	m.StartMatchmaking(envs.GetMatchmakingConfig())
+
+	r := routes.SetupRouter(m, env, envs.GetClientIPHeader())
+	server := &http.Server{Addr: ":8080", Handler: r}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		fmt.Println("Listening on :8080")
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Println("Server error:", err)
+			stop()
+		}
+	}()
+
+	<-ctx.Done()
+	fmt.Println("Shutting down")
+
+	m.BeginShutdown()
+	m.NotifyRestart()
+	if err := m.SaveGames(); err != nil {
+		fmt.Println("Failed to save games:", err)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	server.Shutdown(shutdownCtx)
+}
+
+func newStore(config envs.StoreConfig) (repository.GameStore, error) {
+	if config.Kind == envs.StoreMemory {
+		return repository.NewMemoryStore(), nil
+	}
+	return repository.NewFileStore(config.Dir)
 }
@@ -0,0 +1,10 @@
+#!/bin/sh
+# The game store volume is mounted over /app/data owned by root, hiding the
+# directory made at build time. Make the store directory ours, then drop to
+# the app user. exec keeps the server as PID 1 so it gets SIGTERM on deploys.
+set -e
+
+mkdir -p "$GAME_STORE_DIR"
+chown -R app:app "$GAME_STORE_DIR"
+
+exec su-exec app "$@"
@@ -1,13 +1,21 @@
 import { useMemo, useState } from "react";
 import { Button } from "./Button";
 import { PolicyCard } from "./PolicyCard";
-import { FaThumbsUp, FaThumbsDown, FaPlay } from "react-icons/fa";
-import type { GameState, ActionMessage } from "../types";
+import {
+  FaThumbsUp,
+  FaThumbsDown,
+  FaPlay,
+  FaCheck,
+  FaUserCheck,
+} from "react-icons/fa";
+import type { PlayerView, ActionMessage } from "../types";
 import {
   ActionVote,
   ActionLegislate,
   ActionEndTurn,
   ActionStartGame,
+  ActionReadyCheck,
+  ActionReady,
   Election,
   Legislation1,
   Legislation2,
@@ -18,6 +26,7 @@ import {
   ActionSpecialElection,
   ActionPolicyPeek,
   Setup,
+  ReadyCheck,
   GameOver,
   Paused,
   MessageTypeAction,
@@ -27,7 +36,7 @@ import {
 } from "../types";
 
 interface ActionPanelProps {
-  gameState: GameState;
+  gameState: PlayerView;
   currentPlayerId: string;
   onAction: (action: ActionMessage) => void;
 }
@@ -66,6 +75,8 @@ export function ActionPanel({
         return "NOMINATION - President nominates a chancellor";
       case Setup:
         return "SETUP - Game host sets up the game";
+      case ReadyCheck:
+        return "READY CHECK - Confirm you're ready to play";
       case GameOver:
         return `GAME OVER - Game over ${gameState.winner}`;
       case Paused:
@@ -156,6 +167,27 @@ export function ActionPanel({
     });
   };
 
+  const handleReadyCheck = () => {
+    onAction({
+      action: ActionReadyCheck,
+      base_message: {
+        sender_id: currentPlayerId,
+        type: MessageTypeAction,
+      },
+    });
+  };
+
+  const handleReady = (ready: boolean) => {
+    onAction({
+      action: ActionReady,
+      vote: ready,
+      base_message: {
+        sender_id: currentPlayerId,
+        type: MessageTypeAction,
+      },
+    });
+  };
+
   const renderVotingInterface = () => {
     if (!canVote()) return null;
 
@@ -323,17 +355,20 @@ export function ActionPanel({
     );
   };
 
-  const renderStartGameButton = () => {
+  const renderReadyCheckButton = () => {
     const isHost = currentPlayerId === gameState.host_id;
     const isSetupPhase = gameState.phase === Setup;
+    // Once a ready check runs out, the host can ask again
+    const isExpiredCheck =
+      gameState.phase === ReadyCheck && !gameState.ready_check;
     const hasEnoughPlayers = gameState.players.length >= 5;
 
-    if (!isHost || !isSetupPhase) return null;
+    if (!isHost || !(isSetupPhase || isExpiredCheck)) return null;
 
     return (
       <div className="flex flex-col items-center space-y-4">
         <button
-          onClick={handleStartGame}
+          onClick={handleReadyCheck}
           disabled={!hasEnoughPlayers}
           className={`
             relative overflow-hidden
@@ -350,8 +385,8 @@ export function ActionPanel({
             }
           `}
         >
-          <FaPlay className="text-2xl" />
-          <span>START GAME</span>
+          <FaUserCheck className="text-2xl" />
+          <span>READY CHECK</span>
         </button>
         {!hasEnoughPlayers && (
           <div className="bg-red-200/90 border-4 border-black rounded-lg px-6 py-3 shadow-[4px_4px_0px_black]">
@@ -362,13 +397,65 @@ export function ActionPanel({
         )}
         {hasEnoughPlayers && (
           <p className="text-green-700 font-propaganda text-sm tracking-wide">
-            ✓ Ready to begin!
+            ✓ Ask everyone to confirm they're ready
           </p>
         )}
       </div>
     );
   };
 
+  const renderReadyInterface = () => {
+    if (gameState.phase !== ReadyCheck || !currentPlayer) return null;
+
+    const isHost = currentPlayerId === gameState.host_id;
+    const isReady = currentPlayer.is_ready;
+    // Players who aren't connected don't hold up the start
+    const waitingOn = gameState.players.filter(
+      (player) => player.is_connected && !player.is_ready
+    ).length;
+
+    return (
+      <div className="flex flex-col items-center space-y-4">
+        <Button
+          onClick={() => handleReady(!isReady)}
+          variant={isReady ? "secondary" : "primary"}
+          className="flex items-center space-x-2"
+        >
+          <FaCheck />
+          <span>{isReady ? "NOT READY" : "I'M READY"}</span>
+        </Button>
+        {isHost && (
+          <button
+            onClick={handleStartGame}
+            disabled={waitingOn > 0}
+            className={`
+              relative overflow-hidden
+              font-propaganda text-2xl tracking-wider
+              px-12 py-6
+              border-4 border-black rounded-xl
+              shadow-[6px_6px_0px_black]
+              transition-all duration-200
+              flex items-center justify-center space-x-4
+              ${
+                waitingOn === 0
+                  ? "bg-green-500 hover:bg-green-600 hover:scale-105 active:scale-95 active:shadow-[3px_3px_0px_black] cursor-pointer text-white"
+                  : "bg-gray-400 cursor-not-allowed opacity-60 text-gray-600"
+              }
+            `}
+          >
+            <FaPlay className="text-2xl" />
+            <span>START GAME</span>
+          </button>
+        )}
+        <p className="text-black font-propaganda text-sm tracking-wide">
+          {waitingOn > 0
+            ? `Waiting for ${waitingOn} player(s) to confirm...`
+            : "✓ Everyone is ready!"}
+        </p>
+      </div>
+    );
+  };
+
   return useMemo(
     () => (
       <div
@@ -395,8 +482,11 @@ export function ActionPanel({
         {/* Only show interactive content if player is alive */}
         {!isCurrentPlayerDead && (
           <>
-            {/* Start Game Button (Setup Phase) */}
-            {renderStartGameButton()}
+            {/* Ready Check Button (Setup Phase) */}
+            {renderReadyCheckButton()}
+
+            {/* Ready Toggle and Start Game Button (Ready Check Phase) */}
+            {renderReadyInterface()}
 
             {/* Voting Interface */}
             {renderVotingInterface()}
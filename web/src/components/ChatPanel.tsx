@@ -1,8 +1,8 @@
 import { useEffect, useRef, useState } from "react";
-import type { ChatEntry } from "@types";
+import type { ChatMessage } from "@types";
 
 interface ChatPanelProps {
-  chatHistory: ChatEntry[];
+  chatHistory: ChatMessage[];
   currentPlayerId: string;
   onSend: (text: string) => void;
 }
@@ -1,11 +1,12 @@
 import {
+  ConnectionErrorTypeServerRestarting,
   MessageTypeActionError,
   MessageTypeConnectionError,
   MessageTypeGameState,
   type ActionErrorMessage,
   type ActionMessage,
   type ConnectionErrorMessage,
-  type GameState,
+  type PlayerView,
   type GameStateMessage,
   type Message,
   type MessageType,
@@ -13,6 +14,8 @@ import {
 import { useWebSocket } from "./useWebSocket";
 import { useState } from "react";
 
+const PROTOCOL_VERSION = 1;
+
 export function useGameState(
   gameId: string,
   playerId: string,
@@ -29,7 +32,7 @@ export function useGameState(
   const url = `${wsBaseUrl}/api/v1/play?game=${gameId}&player=${playerId}`;
 
   const [shouldReonnect, setShouldReconnect] = useState<boolean>(true);
-  const [gameState, setGameState] = useState<GameState | null>(null);
+  const [gameState, setGameState] = useState<PlayerView | null>(null);
   const [connectionError, setConnectionError] = useState<string | null>(null);
   const [connectionErrorType, setConnectionErrorType] = useState<number | null>(
     null
@@ -41,6 +44,16 @@ export function useGameState(
     isConnecting,
     lastError,
   } = useWebSocket(url, {
+    onOpen: () => {
+      // The server expects a hello before anything else
+      sendMessageRaw(
+        JSON.stringify({
+          base_message: { type: "hello", sender_id: playerId },
+          protocol_version: PROTOCOL_VERSION,
+          features: [],
+        })
+      );
+    },
     onMessage: (messageEvent) => {
       const data: Message = JSON.parse(messageEvent.data);
 
@@ -62,9 +75,15 @@ export function useGameState(
           setConnectionError(null);
           setConnectionErrorType(null);
           break;
+        case "welcome":
+          break;
         case MessageTypeConnectionError:
           const connErrMessage: ConnectionErrorMessage = data;
-          setShouldReconnect(false);
+          // A restart is the one error worth waiting out, the game comes back
+          // once the server does
+          if (connErrMessage.error_type !== ConnectionErrorTypeServerRestarting) {
+            setShouldReconnect(false);
+          }
           setConnectionError(connErrMessage.reason);
           setConnectionErrorType(connErrMessage.error_type);
           break;
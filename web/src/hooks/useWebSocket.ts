@@ -6,7 +6,8 @@ type Options = {
   onError?: (event: Event) => void;
   onMessage?: (event: MessageEvent) => void;
   reconnect?: boolean; // auto-reconnect on close?
-  reconnectInterval?: number; // ms
+  reconnectInterval?: number; // ms, doubled after each failed attempt
+  maxReconnectInterval?: number; // ms
   deps?: any[];
 };
 
@@ -18,11 +19,14 @@ export function useWebSocket(url: string, options: Options = {}) {
     onMessage,
     reconnect = true,
     reconnectInterval = 2000,
+    maxReconnectInterval = 30000,
     deps = [],
   } = options;
 
   const ws = useRef<WebSocket | null>(null);
   const reconnectTimeout = useRef<number | null>(null);
+  const reconnectAttempts = useRef<number>(0);
+  const openedAt = useRef<number | null>(null);
 
   // Store the latest callbacks in refs to avoid re-creating the connection on each render
   const onOpenRef = useRef<typeof onOpen>(onOpen);
@@ -64,6 +68,7 @@ export function useWebSocket(url: string, options: Options = {}) {
       setIsConnected(true);
       setIsConnecting(false);
       setLastError(null);
+      openedAt.current = Date.now();
       onOpenRef.current?.(event);
     };
 
@@ -71,8 +76,23 @@ export function useWebSocket(url: string, options: Options = {}) {
       setIsConnected(false);
       setIsConnecting(false);
       onCloseRef.current?.(event);
+      // A server that accepts and then turns us away doesn't reset the
+      // backoff, only a connection that stayed up for a while does
+      if (
+        openedAt.current !== null &&
+        Date.now() - openedAt.current > maxReconnectInterval
+      ) {
+        reconnectAttempts.current = 0;
+      }
+      openedAt.current = null;
       if (reconnect) {
-        reconnectTimeout.current = setTimeout(connect, reconnectInterval);
+        // Back off so a restarting server isn't hammered by every client at once
+        const delay = Math.min(
+          reconnectInterval * 2 ** reconnectAttempts.current,
+          maxReconnectInterval
+        );
+        reconnectAttempts.current++;
+        reconnectTimeout.current = setTimeout(connect, delay);
       }
     };
 
@@ -85,7 +105,7 @@ export function useWebSocket(url: string, options: Options = {}) {
     ws.current.onmessage = (event) => {
       onMessageRef.current?.(event);
     };
-  }, [url, reconnect, reconnectInterval, ...deps]);
+  }, [url, reconnect, reconnectInterval, maxReconnectInterval, ...deps]);
 
   useEffect(() => {
     connect();
@@ -1,13 +1,14 @@
 import { Board, Container, ActionPanel, ChatPanel } from "@components";
 import {
-  type GameState,
-  type Player,
+  type PlayerView,
+  type PlayerInfo,
   type PlayerRole,
   type ActionMessage,
   type VoteResult,
   Nomination,
   Executive,
   Setup,
+  ReadyCheck,
   GameOver,
   VotePending,
   VoteJa,
@@ -31,19 +32,20 @@ import {
 } from "react-icons/fa";
 
 interface GameProps {
-  state: GameState;
+  state: PlayerView;
   currentPlayerId: string;
   onAction: (msg: ActionMessage) => void;
   gameId: string;
 }
 
 interface PlayerCardProps {
-  player: Player;
+  player: PlayerInfo;
   index: number;
   isPresident: boolean;
   isChancellor: boolean;
   isNominee: boolean;
   isCurrentPlayer: boolean;
+  showReady: boolean;
   vote?: VoteResult;
   onClick: (playerIndex: number) => void;
 }
@@ -55,6 +57,7 @@ function PlayerCard({
   isChancellor,
   isNominee,
   isCurrentPlayer,
+  showReady,
   vote,
   onClick,
 }: PlayerCardProps) {
@@ -142,6 +145,21 @@ function PlayerCard({
         </div>
       )}
 
+      {/* Ready check indicator */}
+      {showReady && (
+        <div
+          className={`absolute -bottom-2 -left-2 px-2 py-0.5 rounded-full border-2 border-black shadow-[2px_2px_0px_black] ${
+            player.is_ready
+              ? "bg-green-500 text-white"
+              : "bg-gray-300 text-black"
+          }`}
+        >
+          <span className="text-[10px] font-propaganda font-bold tracking-wider">
+            {player.is_ready ? "READY" : "WAITING"}
+          </span>
+        </div>
+      )}
+
       {/* Vote indicator */}
       {vote !== undefined && vote !== VotePending && (
         <div
@@ -183,11 +201,12 @@ function PlayerCard({
 }
 
 interface PlayerRowProps {
-  players: Player[];
+  players: PlayerInfo[];
   presidentIndex: number;
   chancellorIndex: number;
   nomineeIndex: number;
   currentPlayerId: string;
+  showReady: boolean;
   votes?: VoteResult[];
   onPlayerClick: (playerIndex: number) => void;
 }
@@ -198,6 +217,7 @@ function PlayerRow({
   chancellorIndex,
   nomineeIndex,
   currentPlayerId,
+  showReady,
   votes,
   onPlayerClick,
 }: PlayerRowProps) {
@@ -213,6 +233,7 @@ function PlayerRow({
             isChancellor={index === chancellorIndex}
             isNominee={index === nomineeIndex}
             isCurrentPlayer={player.id === currentPlayerId}
+            showReady={showReady}
             vote={votes && votes[index]}
             onClick={onPlayerClick}
           />
@@ -405,7 +426,7 @@ export default function Game({
       </div>
 
       {/* Invite Link Button - Top Right */}
-      {state.phase === Setup && (
+      {(state.phase === Setup || state.phase === ReadyCheck) && (
         <div className="fixed top-6 right-6 z-40">
           <button
             onClick={handleCopyInviteLink}
@@ -457,6 +478,7 @@ export default function Game({
             chancellorIndex={state.chancellor_index}
             nomineeIndex={state.nominee_index}
             currentPlayerId={currentPlayerId}
+            showReady={state.phase === ReadyCheck}
             votes={state.votes}
             onPlayerClick={handlePlayerClick}
           />
@@ -467,8 +489,8 @@ export default function Game({
             <div className="flex-1">
               <Board
                 board={state.board}
-                deckCount={state.deck?.length || 0}
-                discardCount={state.discard?.length || 0}
+                deckCount={state.deck_size}
+                discardCount={state.discard_size}
               />
             </div>
 
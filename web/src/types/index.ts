@@ -1,19 +1,110 @@
 // Code generated by tygo. DO NOT EDIT.
 
+//////////
+// source: errors.go
+
+/**
+ * ErrorResponse is the JSON body of errors clients are expected to act on,
+ * with a stable code alongside the readable message
+ */
+export interface ErrorResponse {
+  code: string;
+  message: string;
+  details?: { [key: string]: any};
+}
+export const ErrorCodeInvalidUsername = "invalid_username";
+export const ErrorCodeReservedUsername = "reserved_username";
+export const ErrorCodeUsernameTaken = "username_taken";
+export const ErrorCodeGameInProgress = "game_in_progress";
+export const ErrorCodeLobbyLocked = "lobby_locked";
+
 //////////
 // source: game.go
 
+export interface CreateGameRequest {
+  streamer_enabled?: boolean;
+  streamer_delay_seconds?: number /* int */;
+  streamer_delay_rounds?: number /* int */;
+  password?: string;
+  invite_only?: boolean;
+  visibility?: string; // public or private, private by default
+}
 export interface CreateGameResponse {
   game_id: string;
+  invite?: string; // For the creator to join an invite-only game with
+}
+export interface ListGamesResponse {
+  games: any /* views.LobbyListing */[];
+}
+export interface GameMetricsResponse {
+  game_id: string;
+  sent: { [key: any /* messages.MessageType */]: any /* game.MessageStats */};
+}
+export interface ServerMetricsResponse {
+  games: number /* int */;
+  reaper: any /* game.ReaperStats */;
 }
 export interface JoinGameRequest {
   game_id: string;
   username: string;
+  password?: string;
+  invite?: string;
 }
 export interface JoinGameResponse {
   game_id: string;
   player_id: string;
 }
+export interface CreateInviteRequest {
+  player_id: string;
+}
+export interface CreateInviteResponse {
+  game_id: string;
+  invite: string;
+}
+
+//////////
+// source: handshake.go
+
+
+//////////
+// source: lobby_ws.go
+
+
+//////////
+// source: matchmaking.go
+
+export interface EnqueueRequest {
+  username: string;
+  preferred_size: number /* int */; // Between 5 and 10
+}
+export interface EnqueueResponse {
+  ticket_id: string;
+}
+
+//////////
+// source: play_sse.go
+
+/**
+ * sseClient is a game.Client that streams messages as server-sent events
+ */
+
+//////////
+// source: play_ws.go
+
+/**
+ * wsClient sends messages over a websocket in the encoding negotiated for it
+ */
+
+//////////
+// source: rate_limit.go
+
+/**
+ * failureLimiter blocks a client after too many failed attempts within a window
+ */
+
+//////////
+// source: spectate_ws.go
+
 // Code generated by tygo. DO NOT EDIT.
 
 //////////
@@ -27,14 +118,42 @@ export interface ActionMessage {
   target_index?: number /* int */;
   vote?: boolean;
   text?: string;
+  seat_order?: number /* int */[]; // Current seat indexes in their new order
 }
-export type ActionErrorReason = string;
-export const NotAllowed: ActionErrorReason = "Action not allowed";
-export const InvalidTarget: ActionErrorReason = "Targeted player is cannot be targeted for this action";
-export const CouldNotStart: ActionErrorReason = "Could not start game";
+/**
+ * ActionErrorCode is a stable, machine-readable reason an action was refused.
+ * Clients should switch on the code and only fall back to Reason for codes
+ * they don't know yet.
+ */
+export type ActionErrorCode = string;
+export const ErrorCodeUnknownAction: ActionErrorCode = "unknown_action";
+export const ErrorCodeUnknownPlayer: ActionErrorCode = "unknown_player";
+export const ErrorCodeNotHost: ActionErrorCode = "not_host";
+export const ErrorCodeNotYourTurn: ActionErrorCode = "not_your_turn";
+export const ErrorCodeWrongPhase: ActionErrorCode = "wrong_phase";
+export const ErrorCodeInvalidTarget: ActionErrorCode = "invalid_target";
+export const ErrorCodeTargetExecuted: ActionErrorCode = "target_executed";
+export const ErrorCodePlayerExecuted: ActionErrorCode = "player_executed";
+export const ErrorCodeTermLimited: ActionErrorCode = "term_limited";
+export const ErrorCodeAlreadyVoted: ActionErrorCode = "already_voted";
+export const ErrorCodeMissingVote: ActionErrorCode = "missing_vote";
+export const ErrorCodeInvalidCard: ActionErrorCode = "invalid_card";
+export const ErrorCodeInvalidChat: ActionErrorCode = "invalid_chat";
+export const ErrorCodeInvalidPlayerCount: ActionErrorCode = "invalid_player_count";
+export const ErrorCodeGracePeriod: ActionErrorCode = "grace_period";
+export const ErrorCodeInvalidSeatOrder: ActionErrorCode = "invalid_seat_order";
+export const ErrorCodeNotReady: ActionErrorCode = "players_not_ready";
+/**
+ * ActionErrorParams carries the details of an error, e.g. the expected phase
+ * for ErrorCodeWrongPhase or the target for ErrorCodeTermLimited
+ */
+export type ActionErrorParams = { [key: string]: any};
 export interface ActionErrorMessage {
   base_message: BaseMessage;
-  reason: ActionErrorReason;
+  action: Action;
+  code: ActionErrorCode;
+  params?: Record<string;
+  reason: string;
 }
 
 //////////
@@ -46,6 +165,20 @@ export const ConnectionErrorTypeFatal: ConnectionErrorType = 0;
 export const ConnectionErrorTypeGameInvalid: ConnectionErrorType = 1;
 export const ConnectionErrorTypePlayerInvalid: ConnectionErrorType = 2;
 export const ConnectionErrorTypeServerError: ConnectionErrorType = 3;
+export const ConnectionErrorTypeOutdatedClient: ConnectionErrorType = 4;
+/**
+ * The server is going down for a restart, the game survives it and
+ * clients should keep trying to reconnect
+ */
+export const ConnectionErrorTypeServerRestarting: ConnectionErrorType = 5;
+/**
+ * The host removed the player from the lobby, their ID is no longer valid
+ */
+export const ConnectionErrorTypeKicked: ConnectionErrorType = 6;
+/**
+ * The matchmaking ticket is unknown, expired or the queue shut down
+ */
+export const ConnectionErrorTypeTicketInvalid: ConnectionErrorType = 7;
 export interface ConnectionErrorMessage {
   base_message: BaseMessage;
   reason: string;
@@ -58,7 +191,68 @@ export interface ConnectionErrorMessage {
 export const MessageTypeGameState: MessageType = "game_state";
 export interface GameStateMessage {
   base_message: BaseMessage;
-  game_state: GameState;
+  game_state: PlayerView;
+  seq?: number /* uint64 */;
+}
+
+//////////
+// source: game_state_patch_message.go
+
+export const MessageTypeGameStatePatch: MessageType = "game_state_patch";
+/**
+ * GameStatePatchMessage carries the changes since the message with sequence
+ * number Seq-1. Clients that notice a gap should send a ResyncMessage.
+ */
+export interface GameStatePatchMessage {
+  base_message: BaseMessage;
+  seq: number /* uint64 */;
+  patch: PatchOperation[];
+}
+
+//////////
+// source: handshake_message.go
+
+export const MessageTypeHello: MessageType = "hello";
+export const MessageTypeWelcome: MessageType = "welcome";
+/**
+ * HelloMessage must be the first message a client sends after connecting
+ */
+export interface HelloMessage {
+  base_message: BaseMessage;
+  protocol_version: number /* int */;
+  features: Feature[];
+}
+/**
+ * WelcomeMessage answers a hello with the version and features in use for the connection
+ */
+export interface WelcomeMessage {
+  base_message: BaseMessage;
+  protocol_version: number /* int */;
+  features: Feature[];
+}
+
+//////////
+// source: lobby_list_message.go
+
+export const MessageTypeLobbyList: MessageType = "lobby_list";
+export interface LobbyListMessage {
+  base_message: BaseMessage;
+  games: LobbyListing[];
+}
+
+//////////
+// source: match_found_message.go
+
+export const MessageTypeMatchFound: MessageType = "match_found";
+/**
+ * MatchFoundMessage tells a queued player which game they were seated in. They
+ * join it like any other game with the IDs it carries.
+ */
+export interface MatchFoundMessage {
+  base_message: BaseMessage;
+  game_id: string;
+  player_id: string;
+  table_size: number /* int */;
 }
 
 //////////
@@ -69,6 +263,78 @@ export type Message = any;
 export interface BaseMessage {
   type: MessageType;
   sender_id: string;
+  /**
+   * RequestID is optionally set by clients on actions and echoed back on
+   * every reply to that action
+   */
+  request_id?: string;
+}
+
+//////////
+// source: msgpack.go
+
+
+//////////
+// source: patch.go
+
+export type PatchOp = string;
+export const PatchOpAdd: PatchOp = "add";
+export const PatchOpRemove: PatchOp = "remove";
+export const PatchOpReplace: PatchOp = "replace";
+/**
+ * PatchOperation is a single RFC 6902 JSON Patch operation
+ */
+export interface PatchOperation {
+  op: PatchOp;
+  path: string;
+  value?: any;
+}
+
+//////////
+// source: protocol.go
+
+/**
+ * ProtocolVersion is bumped whenever a change to the messages would break
+ * clients built against the previous version
+ */
+export const ProtocolVersion = 1;
+/**
+ * Feature is an optional protocol capability a client can ask for in its hello
+ */
+export type Feature = string;
+export const FeatureDeltaUpdates: Feature = "delta_updates";
+/**
+ * FeatureMsgPack switches every message after the welcome to binary
+ * MessagePack frames, in both directions
+ */
+export const FeatureMsgPack: Feature = "msgpack";
+/**
+ * Encoding is the wire format a connection uses once the handshake is done
+ */
+export type Encoding = string;
+export const EncodingJSON: Encoding = "json";
+export const EncodingMsgPack: Encoding = "msgpack";
+
+//////////
+// source: resync_message.go
+
+export const MessageTypeResync: MessageType = "resync";
+/**
+ * ResyncMessage asks the server for a full game state snapshot
+ */
+export interface ResyncMessage {
+  base_message: BaseMessage;
+}
+
+//////////
+// source: streamer_state_message.go
+
+export const MessageTypeStreamerState: MessageType = "streamer_state";
+export interface StreamerStateMessage {
+  base_message: BaseMessage;
+  game_state: StreamerView;
+  delay_seconds: number /* int */;
+  delay_rounds: number /* int */;
 }
 // Code generated by tygo. DO NOT EDIT.
 
@@ -89,6 +355,16 @@ export const ActionProposeVeto: Action = "propose_veto";
 export const ActionApproveVeto: Action = "approve_veto";
 export const ActionRejectVeto: Action = "reject_veto";
 export const ActionEndTurn: Action = "end_turn";
+export const ActionVoteContinue: Action = "vote_continue"; // Go on without players who left
+export const ActionAbortGame: Action = "abort_game";
+export const ActionKickPlayer: Action = "kick_player";
+export const ActionTransferHost: Action = "transfer_host";
+export const ActionLockLobby: Action = "lock_lobby";
+export const ActionUnlockLobby: Action = "unlock_lobby";
+export const ActionReorderSeats: Action = "reorder_seats";
+export const ActionReadyCheck: Action = "ready_check"; // Host asks everyone to confirm they're ready
+export const ActionReady: Action = "ready";
+export const ActionRematch: Action = "rematch"; // From the host starts one, from anyone else it's a vote
 export const ActionNone: Action = "none";
 
 //////////
@@ -119,6 +395,14 @@ export const CardHidden: Card = "hidden";
 //////////
 // source: game_state.go
 
+/**
+ * A table seats between MinPlayers and MaxPlayers players
+ */
+export const MinPlayers = 5;
+/**
+ * A table seats between MinPlayers and MaxPlayers players
+ */
+export const MaxPlayers = 10;
 export type VoteResult = number /* int */;
 export const VotePending: VoteResult = 0;
 export const VoteHidden: VoteResult = 1;
@@ -130,10 +414,17 @@ export interface ChatEntry {
   text: string;
   sent_at_unix: number /* int64 */;
 }
-export interface GameState {
-  players: Player[];
+/**
+ * PolicyPiles holds the real draw and discard piles. It never leaves the
+ * server, clients only ever see the pile sizes through views.PlayerView.
+ */
+export interface PolicyPiles {
   deck: Card[];
   discard: Card[];
+}
+export interface GameState {
+  players: Player[];
+  piles: PolicyPiles;
   board: Board;
   president_index: number /* int */;
   chancellor_index: number /* int */;
@@ -147,9 +438,62 @@ export interface GameState {
   peeker_index?: number /* int */;
   resume_order_index?: number /* int */; // Post special election
   resume_phase?: GamePhase;
+  pause?: PauseInfo;
   winner?: Team;
+  win_reason?: WinReason;
+  continue_votes?: VoteResult[]; // Vote to go on without missing players
   host_id: string;
+  lobby_locked: boolean;
+  ready_check?: ReadyCheckInfo;
   chat_history: ChatEntry[];
+  round: number /* int */;
+  history: RoundRecord[];
+  match_number: number /* int */; // Counts rematches at the same table, from 1
+  starting_president: number /* int */; // First president of this match, set up ahead of time for a rematch
+  rematch_votes?: VoteResult[];
+}
+
+//////////
+// source: history.go
+
+/**
+ * RoundRecord is what happened in a single election: who ran, how everyone
+ * voted, and what came of it
+ */
+export interface RoundRecord {
+  round: number /* int */;
+  president_index: number /* int */;
+  chancellor_index: number /* int */;
+  votes: VoteResult[];
+  elected: boolean;
+  enacted_policy?: Card;
+  chaos_policy?: boolean; // Enacted by the election tracker
+  executive_action?: Action;
+  action_target: number /* int */;
+}
+
+//////////
+// source: pause.go
+
+export type PauseReason = string;
+export const PauseReasonDisconnected: PauseReason = "player_disconnected";
+export const PauseReasonServerRestart: PauseReason = "server_restart";
+/**
+ * WaitingPlayer is a missing player the game is paused for. Once
+ * VoteOpensAtUnix has passed the table may vote to go on without them.
+ */
+export interface WaitingPlayer {
+  index: number /* int */;
+  disconnected_at_unix: number /* int64 */;
+  vote_opens_at_unix: number /* int64 */;
+}
+/**
+ * PauseInfo explains why a paused game is paused and who it is waiting for
+ */
+export interface PauseInfo {
+  reason: PauseReason;
+  since_unix: number /* int64 */;
+  waiting_for: WaitingPlayer[];
 }
 
 //////////
@@ -157,6 +501,7 @@ export interface GameState {
 
 export type GamePhase = string;
 export const Setup: GamePhase = "setup"; // TODO
+export const ReadyCheck: GamePhase = "ready_check";
 export const Nomination: GamePhase = "nomination";
 export const Election: GamePhase = "election";
 export const Legislation1: GamePhase = "legislation1";
@@ -180,6 +525,20 @@ export interface Player {
   role: PlayerRole;
   is_executed: boolean;
   is_connected: boolean;
+  is_abandoned: boolean; // Voted out by the table after leaving
+  is_ready: boolean;
+  disconnected_at_unix?: number /* int64 */;
+}
+
+//////////
+// source: ready.go
+
+/**
+ * ReadyCheckInfo tracks the ready check run before the game starts
+ */
+export interface ReadyCheckInfo {
+  started_at_unix: number /* int64 */;
+  deadline_unix: number /* int64 */; // Players not ready by then are removed
 }
 
 //////////
@@ -189,3 +548,126 @@ export type Team = string;
 export const TeamUnassigned: Team = "UNASSIGNED";
 export const TeamFascist: Team = "FASCISTS";
 export const TeamLiberal: Team = "LIBERALS";
+/**
+ * WinReason is how the game was decided
+ */
+export type WinReason = string;
+export const WinReasonNone: WinReason = "";
+export const WinReasonLiberalPolicies: WinReason = "liberal_policies";
+export const WinReasonFascistPolicies: WinReason = "fascist_policies";
+export const WinReasonHitlerExecuted: WinReason = "hitler_executed";
+export const WinReasonHitlerElected: WinReason = "hitler_elected";
+export const WinReasonAborted: WinReason = "aborted"; // Ended early by the host, nobody wins
+
+//////////
+// source: username.go
+
+export const MinUsernameLength = 2;
+export const MaxUsernameLength = 20;
+// Code generated by tygo. DO NOT EDIT.
+
+//////////
+// source: lobby_view.go
+
+/**
+ * LobbyListing is what the public lobby browser shows about a game waiting
+ * for players
+ */
+export interface LobbyListing {
+  game_id: string;
+  host_name: string;
+  player_count: number /* int */;
+  max_players: number /* int */;
+  variant: string;
+  password_protected: boolean;
+  streamed: boolean;
+  created_at_unix: number /* int64 */;
+}
+
+//////////
+// source: player_view.go
+
+/**
+ * PlayerInfo is what a recipient may know about a single seat at the table
+ */
+export interface PlayerInfo {
+  id: string;
+  username: string;
+  role: PlayerRole;
+  is_executed: boolean;
+  is_connected: boolean;
+  is_abandoned: boolean;
+  is_ready: boolean;
+}
+export interface ChatMessage {
+  sender_id: string;
+  sender_name: string;
+  text: string;
+  sent_at_unix: number /* int64 */;
+}
+/**
+ * PlayerView is the game as seen by one player. Every field is filled in
+ * explicitly from the domain state, so anything added to models.GameState stays
+ * on the server until it is deliberately added here.
+ */
+export interface PlayerView {
+  players: PlayerInfo[];
+  deck_size: number /* int */;
+  discard_size: number /* int */;
+  board: Board;
+  president_index: number /* int */;
+  chancellor_index: number /* int */;
+  prev_president_index: number /* int */;
+  prev_chancellor_index: number /* int */;
+  nominee_index: number /* int */;
+  phase: GamePhase;
+  votes?: VoteResult[];
+  pending_action?: Action;
+  peeked_cards?: Card[];
+  peeker_index?: number /* int */;
+  resume_order_index?: number /* int */; // Post special election
+  resume_phase?: GamePhase;
+  pause?: PauseInfo;
+  winner?: Team;
+  win_reason?: WinReason;
+  continue_votes?: VoteResult[];
+  host_id: string;
+  host_index: number /* int */;
+  lobby_locked: boolean;
+  ready_check?: ReadyCheckInfo;
+  chat_history: ChatMessage[];
+  round: number /* int */;
+  match_number: number /* int */;
+  rematch_votes?: VoteResult[];
+}
+
+//////////
+// source: streamer_view.go
+
+/**
+ * StreamerView reveals every role and card for the delayed streamer feed. It
+ * still carries no player IDs so the feed can't be used to impersonate anyone.
+ */
+export interface StreamerView extends PlayerView {
+  deck: Card[];
+  discard: Card[];
+}
+
+//////////
+// source: summary_view.go
+
+/**
+ * GameSummary is the post-game record kept after the live game is gone. Every
+ * role is revealed, but like the other views it carries no player IDs.
+ */
+export interface GameSummary {
+  game_id: string;
+  match_number: number /* int */;
+  winner: Team;
+  win_reason: WinReason;
+  players: PlayerInfo[];
+  board: Board;
+  rounds: RoundRecord[];
+  chat_history: ChatMessage[];
+  finished_at_unix: number /* int64 */;
+}
@@ -15,7 +15,15 @@ export const makeRequest = async <T>(
   });
 
   if (!res.ok) {
-    const message = await res.text();
+    let message = await res.text();
+    // Some errors come back as JSON with a code and a readable message
+    if (res.headers.get("Content-Type")?.includes("application/json")) {
+      try {
+        message = JSON.parse(message).message || message;
+      } catch {
+        // Keep the raw body
+      }
+    }
     if (message) {
       throw new Error(message);
     } else {